@@ -44,6 +44,8 @@ type Node struct {
 	Data      string
 	Namespace string
 	Attr      []Attribute
+	// modified: 节点在源码中的行号(从1开始), 0表示未知
+	Line int
 }
 
 // InsertBefore inserts newChild as a child of n, immediately before oldChild
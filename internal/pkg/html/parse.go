@@ -5,6 +5,7 @@
 package html
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	a "github.com/zbysir/go-vue-ssr/internal/pkg/html/atom"
@@ -19,6 +20,8 @@ type parser struct {
 	tokenizer *Tokenizer
 	// tok is the most recently read token.
 	tok Token
+	// modified: 已读取的换行数, 以及tok所在的行号
+	lines, tokLine int
 	// Self-closing tags like <hr/> are treated as start tags, except that
 	// hasSelfClosingToken is set while they are being processed.
 	hasSelfClosingToken bool
@@ -223,6 +226,10 @@ loop:
 // addChild adds a child node n to the top element, and pushes n onto the stack
 // of open elements if it is an element node.
 func (p *parser) addChild(n *Node) {
+	// modified: 记录行号
+	if n.Line == 0 {
+		n.Line = p.tokLine
+	}
 	if p.shouldFosterParent() {
 		p.fosterParent(n)
 	} else {
@@ -249,6 +256,10 @@ func (p *parser) shouldFosterParent() bool {
 // fosterParent adds a child node according to the foster parenting rules.
 // Section 12.2.6.1, "foster parenting".
 func (p *parser) fosterParent(n *Node) {
+	// modified: 记录行号
+	if n.Line == 0 {
+		n.Line = p.tokLine
+	}
 	var table, parent, prev, template *Node
 	var i int
 	for i = len(p.oe) - 1; i >= 0; i-- {
@@ -2285,7 +2296,9 @@ func (p *parser) parse() error {
 		n := p.oe.top()
 		p.tokenizer.AllowCDATA(n != nil && n.Namespace != "")
 		// Read and parse the next token.
+		p.tokLine = p.lines + 1
 		p.tokenizer.Next()
+		p.lines += bytes.Count(p.tokenizer.Raw(), []byte("\n"))
 		p.tok = p.tokenizer.Token()
 		if p.tok.Type == ErrorToken {
 			err = p.tokenizer.Err()
@@ -65,6 +65,8 @@ func (t TokenType) String() string {
 // Namespace is only used by the parser, not the tokenizer.
 type Attribute struct {
 	Namespace, Key, Val string
+	// modified: 是否是没有值的属性, 如<input disabled>, 用于区分disabled与disabled=""
+	NoValue bool
 }
 
 // A Token consists of a TokenType and some Data (tag name for start and end
@@ -1167,6 +1169,19 @@ func (z *Tokenizer) TagAttr() (key, val []byte, moreAttr bool) {
 	return nil, nil, false
 }
 
+// modified: 判断属性是否没有值, 如<input disabled>.
+// 没有值时readTagAttrVal不会移动值的位置, 值的前一个字符是属性名的最后一个字符, 而不是引号或=
+func (z *Tokenizer) attrNoValue(x [2]span) bool {
+	if x[1].start != x[1].end || x[1].start == 0 {
+		return false
+	}
+	switch z.buf[x[1].start-1] {
+	case '"', '\'', '=':
+		return false
+	}
+	return true
+}
+
 // Token returns the current Token. The result's Data and Attr values remain
 // valid after subsequent Next calls.
 func (z *Tokenizer) Token() Token {
@@ -1179,7 +1194,7 @@ func (z *Tokenizer) Token() Token {
 		for moreAttr {
 			var key, val []byte
 			key, val, moreAttr = z.TagAttr()
-			t.Attr = append(t.Attr, Attribute{"", atom.String(key), string(val)})
+			t.Attr = append(t.Attr, Attribute{"", atom.String(key), string(val), z.attrNoValue(z.attr[z.nAttrReturned-1])})
 		}
 		if a := atom.Lookup(name); a != 0 {
 			t.DataAtom, t.Data = a, a.String()
@@ -0,0 +1,59 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:d524dd4c5abfc6df47161d25313bf7e0
+
+package cacheexpr
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_avatarList(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("avatarList", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("avatarList", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("items"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("i", index)
+				scope.Set("item", item)
+				w.WriteString("<li" + mixinAttr(nil, nil, Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": cachedGet(r, scope, "user", "profile", "name")}}) + "><img" + mixinAttr(nil, nil, Props{orderKey: []string{"src", "alt"}, data: map[string]interface{}{"src": cachedGet(r, scope, "user", "profile", "avatar"), "alt": cachedGet(r, scope, "user", "profile", "name")}}) + "/><span>")
+				w.WriteString(interfaceToStr(cachedGet(r, scope, "item", "name"), true))
+				w.WriteString("</span>")
+
+				if interfaceToBool(cachedGet(r, scope, "user", "profile", "vip")) {
+					w.WriteString("<em>")
+					w.WriteString(interfaceToStr(cachedGet(r, scope, "user", "profile", "name"), true))
+					w.WriteString("</em>")
+				}
+
+				forRange(r, cachedGet(r, scope, "item", "tags"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("tag", item)
+					w.WriteString("<b>")
+					w.WriteString(interfaceToStr(cachedGet(r, scope, "tag", "name"), true))
+					w.WriteString("</b>")
+					releaseScope(r, scope)
+				})
+
+				w.WriteString("<i>")
+				w.WriteString(interfaceToStr(interfaceToFunc(cachedGet(r, scope, "user", "greet"))(r, options, cachedGet(r, scope, "item", "name")), true))
+				w.WriteString("</i></li>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
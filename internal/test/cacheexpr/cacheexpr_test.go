@@ -0,0 +1,116 @@
+// go-vue-ssr -src=./vue -to=./ -pkg=cacheexpr -cache-expr
+// go-vue-ssr -src=./vue -to=./nocache -pkg=nocache
+
+package cacheexpr
+
+import (
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/internal/test/cacheexpr/nocache"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func props(n int, greet interface{}) map[string]interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"id":   i,
+			"name": fmt.Sprintf("item-%d", i),
+			"tags": []interface{}{
+				map[string]interface{}{"name": fmt.Sprintf("tag-%d", i)},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"items": items,
+		"user": map[string]interface{}{
+			"profile": map[string]interface{}{
+				"name":   "bysir",
+				"avatar": "/a.png",
+				"vip":    true,
+			},
+			"greet": greet,
+		},
+	}
+}
+
+// 有副作用的方法: 记录调用次数
+func greet(calls *int) func(args ...interface{}) interface{} {
+	return func(args ...interface{}) interface{} {
+		*calls++
+		return fmt.Sprintf("hi %v", args[0])
+	}
+}
+
+func render(p map[string]interface{}) string {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("avatarList", w, &Options{Props: NewProps(p)})
+	return w.Result()
+}
+
+func renderNoCache(p map[string]interface{}) string {
+	r := nocache.NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("avatarList", w, &nocache.Options{Props: nocache.NewProps(p)})
+	return w.Result()
+}
+
+func TestCacheExpr(t *testing.T) {
+	calls := 0
+	f := greet(&calls)
+	html := render(props(2, Function(func(r *Render, options *Options, args ...interface{}) interface{} {
+		return f(args...)
+	})))
+
+	want := `<ul><li title="bysir"><img src="/a.png" alt="bysir"/><span>item-0</span><em>bysir</em><b>tag-0</b><i>hi item-0</i></li>` +
+		`<li title="bysir"><img src="/a.png" alt="bysir"/><span>item-1</span><em>bysir</em><b>tag-1</b><i>hi item-1</i></li></ul>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+	// 方法调用不会被缓存
+	if calls != 2 {
+		t.Fatalf("greet called %d times; want 2", calls)
+	}
+
+	if noCache := renderNoCache(props(2, nocache.Function(func(r *nocache.Render, options *nocache.Options, args ...interface{}) interface{} {
+		return f(args...)
+	}))); html != noCache {
+		t.Fatalf("cached html = %s; not cached: %s", html, noCache)
+	}
+
+	// 只有多级路径会使用cachedGet, 方法本身可以被缓存, 但调用的结果不会
+	code, err := ioutil.ReadFile("avatarList.vue.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`cachedGet(r, scope, "user", "profile", "avatar")`, `scope.Get("items")`, `interfaceToFunc(cachedGet(r, scope, "user", "greet"))(r, options, `} {
+		if !strings.Contains(string(code), s) {
+			t.Fatalf("code should contain %s", s)
+		}
+	}
+}
+
+func BenchmarkCacheExpr(b *testing.B) {
+	calls := 0
+	f := greet(&calls)
+	b.Run("cache", func(b *testing.B) {
+		p := props(1000, Function(func(r *Render, options *Options, args ...interface{}) interface{} {
+			return f(args...)
+		}))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			render(p)
+		}
+	})
+	b.Run("nocache", func(b *testing.B) {
+		p := props(1000, nocache.Function(func(r *nocache.Render, options *nocache.Options, args ...interface{}) interface{} {
+			return f(args...)
+		}))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			renderNoCache(p)
+		}
+	})
+}
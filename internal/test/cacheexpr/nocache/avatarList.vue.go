@@ -0,0 +1,59 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:be0506d6d698ddd676497b933865c6fb
+
+package nocache
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_avatarList(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("avatarList", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("avatarList", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("items"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("i", index)
+				scope.Set("item", item)
+				w.WriteString("<li" + mixinAttr(nil, nil, Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": scope.Get("user", "profile", "name")}}) + "><img" + mixinAttr(nil, nil, Props{orderKey: []string{"src", "alt"}, data: map[string]interface{}{"src": scope.Get("user", "profile", "avatar"), "alt": scope.Get("user", "profile", "name")}}) + "/><span>")
+				w.WriteString(interfaceToStr(scope.Get("item", "name"), true))
+				w.WriteString("</span>")
+
+				if interfaceToBool(scope.Get("user", "profile", "vip")) {
+					w.WriteString("<em>")
+					w.WriteString(interfaceToStr(scope.Get("user", "profile", "name"), true))
+					w.WriteString("</em>")
+				}
+
+				forRange(r, scope.Get("item", "tags"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("tag", item)
+					w.WriteString("<b>")
+					w.WriteString(interfaceToStr(scope.Get("tag", "name"), true))
+					w.WriteString("</b>")
+					releaseScope(r, scope)
+				})
+
+				w.WriteString("<i>")
+				w.WriteString(interfaceToStr(interfaceToFunc(scope.Get("user", "greet"))(r, options, scope.Get("item", "name")), true))
+				w.WriteString("</i></li>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
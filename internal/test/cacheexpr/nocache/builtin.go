@@ -0,0 +1,3063 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+
+package nocache
+
+
+// src: ./generotor_builtin_source/source.go
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/pkg/ssrtool"
+	"github.com/zbysir/go-vue-ssr/pkg/ssrtool/rinterface"
+	"hash/fnv"
+	"html"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+type Render struct {
+	// 用在模板的全局变量, 可以理解为js中的windows, 每个组件中都可以直接读取到这个对象中的值.
+	// 其中可以存放常量 与 方法
+	Global *Scope
+
+	// 上下文, 你可以在上下文存储任何东西, 方便在多个方法或者指令之间(而不是模板中)共用变量
+	Store Store
+
+	// 内容安全策略(CSP)的nonce, 不为空时会在所有<script>与<style>节点上添加nonce属性
+	Nonce string
+
+	// 当前的语言(如zh-CN, ar-EG), 不为空时会在<html>节点上添加lang与dir属性, 代替模板中静态的lang与dir.
+	// dir根据语言判断, 见LocaleDir. 模板中使用:lang/:dir绑定时不会添加
+	Locale string
+
+	// 注册的动态组件
+	components map[string]ComponentFunc
+	// 指令
+	directives map[string]DirectivesFunc
+	// 过滤器
+	filters map[string]FilterFunc
+	// 渲染未注册的组件
+	placeholder PlaceholderFunc
+	// v-for最大循环次数, 0为不限制
+	maxForIterations int
+	maxDepth         int
+	propTypes        map[string]map[string]PropType
+	estimatedSize    int
+	warn             func(format string, args ...interface{})
+	writerCreator    func() Writer
+	voidElements     map[string]bool
+	canonicalAttrs   bool
+	xhtml            bool
+	sanitizeURL      bool
+	componentHook    func(name string, html string) string // 见RenderCreator.OnComponentRendered
+	instrument       bool
+	extractStyles    bool
+	fallbackTag      string
+	strictComponents bool
+	memo             *memoCache
+
+	// 用于取消渲染, 见RenderContext. done是ctx.Done(), 缓存下来使每次检查只需要一次非阻塞的接收
+	ctx        context.Context
+	done       <-chan struct{}
+	cancelOnce sync.Once
+	cancelErr  error
+	// 渲染出错时为1, 见fail
+	failed int32
+	// 表达式的缓存, 见cachedGet
+	exprCache map[exprKey]interface{}
+	exprMu    sync.RWMutex
+	// <teleport>收集的内容, key是to
+	teleports  map[string][]string
+	teleportMu sync.Mutex
+	// 渲染时收集的元信息, 见RenderFull
+	meta   renderMeta
+	metaMu sync.Mutex
+	// 收集的样式表, 见RenderCreator.ExtractStyles
+	styles     []string
+	styleNames map[string]string // class名 -> css
+	stylesMu   sync.Mutex
+	// 每个组件的渲染次数与耗时, 见RenderCreator.Instrument
+	stats   map[string]*ComponentStat
+	statsMu sync.Mutex
+
+	// 启动的异步渲染(<async>)次数, 用于判断作用域是否可以归还到对象池, 见releaseScope
+	asyncCount int32
+	// 正在执行的异步渲染数量, 见memo
+	asyncRunning int32
+	// v-memo渲染时记录的副作用(收集的组件, 标题, teleport与样式表等), 使用缓存时重新执行, 见memo
+	effects   []func(r *Render)
+	recording int32
+	effectsMu sync.Mutex
+	// 正在执行的Render的嵌套层数, 为0时说明是一次新的渲染, 见Render
+	depth int32
+
+	// 一个Render可能不只一个Write, 多个Write可能并行
+}
+
+func (r *Render) NewWriter() Writer {
+	return r.writerCreator()
+}
+
+// 开始渲染组件, 如果设置了RenderCreator.OnComponentRendered, 组件会渲染到返回的Writer中, 调用done后将处理过的html写入w
+// 开启了RenderCreator.Instrument时, 调用done会记录组件的渲染次数与耗时
+func (r *Render) hookComponent(name string, w Writer) (Writer, func()) {
+	if r.componentHook == nil && !r.instrument {
+		return w, noopDone
+	}
+	var start time.Time
+	if r.instrument {
+		start = time.Now()
+	}
+	cw := w
+	if r.componentHook != nil {
+		cw = r.NewWriter()
+	}
+	return cw, func() {
+		if r.componentHook != nil {
+			w.WriteString(r.componentHook(name, cw.Result()))
+		}
+		if r.instrument {
+			r.recordStat(name, time.Since(start))
+		}
+	}
+}
+
+// 组件的渲染统计, 见RenderCreator.Instrument
+type ComponentStat struct {
+	// 渲染次数
+	Count int
+	// 累计耗时, 包括子组件的耗时
+	Time time.Duration
+}
+
+func (r *Render) recordStat(name string, d time.Duration) {
+	r.statsMu.Lock()
+	s, ok := r.stats[name]
+	if !ok {
+		if r.stats == nil {
+			r.stats = map[string]*ComponentStat{}
+		}
+		s = &ComponentStat{}
+		r.stats[name] = s
+	}
+	s.Count++
+	s.Time += d
+	r.statsMu.Unlock()
+}
+
+// 返回本次渲染中每个组件的渲染次数与累计耗时, key是组件名. 需要开启RenderCreator.Instrument, 否则返回nil
+// 只统计模板生成的组件, 不包括Go代码实现的组件; v-memo命中缓存时其中的组件不会被统计, 所以次数可能少于实际渲染的次数
+func (r *Render) Stats() map[string]ComponentStat {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	if r.stats == nil {
+		return nil
+	}
+	m := make(map[string]ComponentStat, len(r.stats))
+	for k, v := range r.stats {
+		m[k] = *v
+	}
+	return m
+}
+
+func noopDone() {}
+
+// 注册只在这个Render中可用的方法, 会覆盖RenderCreator.Func注册的同名方法, 可用于依赖请求的方法(如当前用户的权限判断)
+// 和Render.Global中的变量一样, 方法在同一个Render的多次渲染之间保留, 每个请求应该使用新的Render
+func (r *Render) Func(name string, f Function) {
+	r.Global.Set(name, f)
+}
+
+// 注册只在本次渲染中可用的计算属性, 在表达式中可以像变量一样使用(如{{fullName}}), 会覆盖RenderCreator.Var与Render.Func注册的同名变量.
+// 第一次读取时才会调用f, 结果在本次渲染中缓存, 再次渲染时会重新计算. 和变量一样, 组件的props与v-for等声明的变量会覆盖同名的计算属性.
+func (r *Render) Computed(name string, f func(r *Render) interface{}) {
+	if r.Global.computed == nil {
+		r.Global.computed = map[string]*computedProp{}
+	}
+	r.Global.computed[name] = &computedProp{f: func() interface{} {
+		return f(r)
+	}}
+}
+
+// 渲染注册的组件
+// 一个Render可以依次渲染多次(不能并行), 每次从顶层开始渲染时会清空上一次渲染的状态(取消, teleport, 元信息与样式表等)
+func (r *Render) Render(name string, w Writer, options *Options) {
+	if options.P == nil && atomic.LoadInt32(&r.depth) == 0 {
+		r.reset()
+	}
+	r.render(name, w, options)
+}
+
+// 清空上一次渲染的状态
+func (r *Render) reset() {
+	r.ctx = nil
+	r.done = nil
+	r.cancelOnce = sync.Once{}
+	r.cancelErr = nil
+	atomic.StoreInt32(&r.failed, 0)
+	r.exprCache = nil
+	r.teleports = nil
+	r.meta = renderMeta{}
+	r.styles = nil
+	r.styleNames = nil
+	r.effects = nil
+	r.stats = nil
+	// 计算属性的注册会保留, 但缓存的值只在一次渲染中有效
+	for name, c := range r.Global.computed {
+		r.Global.computed[name] = &computedProp{f: c.f}
+	}
+}
+
+func (r *Render) render(name string, w Writer, options *Options) {
+	atomic.AddInt32(&r.depth, 1)
+	defer atomic.AddInt32(&r.depth, -1)
+
+	r.grow(w)
+	if c, ok := r.components[name]; ok {
+		r.rendered(name)
+		c(r, w, options)
+		return
+	}
+	// 没有设置Placeholder时不输出任何内容
+	if r.placeholder != nil {
+		r.placeholder(r, w, name, options)
+	}
+}
+
+// 预分配输出缓冲, 见RenderCreator.EstimatedSize
+// 只有在w还没有写入内容时(即顶层渲染)才会预分配
+func (r *Render) grow(w Writer) {
+	if r.estimatedSize <= 0 {
+		return
+	}
+	if b, ok := w.(*BufferWriter); ok && b.s.Len() == 0 {
+		b.s.Grow(r.estimatedSize)
+	}
+}
+
+// 使用ctx渲染注册的组件, 当ctx被取消(或超时)时会停止渲染, 此时w中存放的是已经渲染的部分, 并返回ctx.Err().
+// 渲染在v-for的每次循环与每个组件开始时检查ctx是否被取消.
+func (r *Render) RenderContext(ctx context.Context, name string, w Writer, options *Options) error {
+	r.reset()
+	r.ctx = ctx
+	r.done = ctx.Done()
+	r.render(name, w, options)
+	return r.cancelErr
+}
+
+// 停止渲染, RenderContext/RenderFull会返回err
+func (r *Render) fail(err error) {
+	r.cancelOnce.Do(func() {
+		r.cancelErr = err
+	})
+	atomic.StoreInt32(&r.failed, 1)
+}
+
+// 判断渲染是否被取消
+func (r *Render) canceled() bool {
+	if atomic.LoadInt32(&r.failed) == 1 {
+		return true
+	}
+	// 没有使用RenderContext或者ctx不能被取消(如context.Background())时done为nil
+	if r.done == nil {
+		return false
+	}
+	select {
+	case <-r.done:
+	default:
+		return false
+	}
+
+	err := r.ctx.Err()
+	r.cancelOnce.Do(func() {
+		r.cancelErr = err
+	})
+	return true
+}
+
+// 渲染时收集的元信息
+type renderMeta struct {
+	components map[string]bool
+	slots      map[string]bool
+	title      string
+	head       []headItem
+}
+
+// v-head收集的节点, key用于去重
+type headItem struct {
+	key  string
+	html string
+}
+
+// RenderFull的结果
+type RenderResult struct {
+	// 渲染的html
+	Body string
+	// 渲染过的组件名(包括Go代码实现的组件), 已排序
+	Components []string
+	// 最后一个渲染的<title>节点的内容
+	Title string
+	// 由<teleport to="to">收集的内容, key是to
+	Teleports map[string]string
+	// 由v-head收集的节点, 同Render.Head
+	Head string
+	// 渲染过的插槽名(<slot name="name">), 已排序
+	Slots []string
+	// 开启了RenderCreator.ExtractStyles时收集的样式表, 同Render.Styles
+	Styles string
+}
+
+// 渲染注册的组件, 除了html外还返回渲染时收集的元信息(渲染过的组件, 标题, teleport的内容等).
+// error只会在渲染被取消时返回, 见RenderContext
+func (r *Render) RenderFull(name string, props map[string]interface{}) (RenderResult, error) {
+	w := r.NewWriter()
+	r.Render(name, w, &Options{Props: NewProps(props)})
+	// Result会等待异步渲染完成, 所以需要在读取元信息之前调用
+	body := w.Result()
+
+	r.metaMu.Lock()
+	res := RenderResult{
+		Body:       body,
+		Components: sortedKeys(r.meta.components),
+		Title:      r.meta.title,
+		Slots:      sortedKeys(r.meta.slots),
+		Head:       r.head(),
+	}
+	r.metaMu.Unlock()
+
+	res.Styles = r.Styles()
+
+	r.teleportMu.Lock()
+	if len(r.teleports) != 0 {
+		res.Teleports = make(map[string]string, len(r.teleports))
+		for to, parts := range r.teleports {
+			res.Teleports[to] = strings.Join(parts, "")
+		}
+	}
+	r.teleportMu.Unlock()
+
+	return res, r.cancelErr
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 在每个组件开始渲染时调用, 记录组件的嵌套深度, 超过r.maxDepth时返回false, 组件不应该再渲染
+func (r *Render) enter(name string, options *Options) bool {
+	options.depth = 1
+	if options.P != nil {
+		options.depth = options.P.depth + 1
+	}
+	if r.maxDepth > 0 && options.depth > r.maxDepth {
+		if r.warn != nil {
+			r.warn("component %s not rendered: depth %d > MaxDepth(%d)", name, options.depth, r.maxDepth)
+		}
+		return false
+	}
+
+	if types, ok := r.propTypes[name]; ok {
+		r.coerceProps(name, types, options)
+	}
+
+	r.rendered(name)
+	return true
+}
+
+// prop的类型, 见RenderCreator.DeclareProps
+type PropKind int
+
+const (
+	PropAny    PropKind = iota // 不转换
+	PropString                 // 转为string
+	PropNumber                 // 字符串转为int(整数)或float64
+	PropBool                   // 字符串转为bool, 空字符串(如<c disabled>)为true
+)
+
+type PropType struct {
+	Kind     PropKind
+	Required bool        // 是否必须传递
+	Default  interface{} // 没有传递时的默认值, 为nil时没有默认值
+}
+
+// 按声明的类型转换options中的props, 不会修改原来的props
+func (r *Render) coerceProps(name string, types map[string]PropType, options *Options) {
+	props := Props{}
+	for _, k := range options.Props.orderKey {
+		props.Set(k, options.Props.data[k])
+	}
+
+	keys := make([]string, 0, len(types))
+	for k := range types {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		t := types[k]
+		v, ok := props.Get(k)
+		if !ok {
+			if attr, has := options.Attrs.Get(k); has {
+				v, ok = attr.Val, true
+				options.Attrs = options.Attrs.omit(k)
+			}
+		}
+		if !ok && t.Default != nil {
+			v, ok = t.Default, true
+		}
+		if !ok {
+			if t.Required && r.warn != nil {
+				r.warn("component %s: missing required prop %q", name, k)
+			}
+			continue
+		}
+
+		cv, err := coerceProp(t.Kind, v)
+		if err != nil {
+			if r.warn != nil {
+				r.warn("component %s: prop %q: %v", name, k, err)
+			}
+			cv = v
+		}
+		props.Set(k, cv)
+	}
+
+	options.Props = props
+}
+
+func coerceProp(kind PropKind, v interface{}) (interface{}, error) {
+	switch kind {
+	case PropString:
+		return interfaceToStr(v), nil
+	case PropNumber:
+		switch a := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return v, nil
+		case string:
+			s := strings.TrimSpace(a)
+			if i, err := strconv.Atoi(s); err == nil {
+				return i, nil
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f, nil
+			}
+		}
+		return nil, fmt.Errorf("can't convert %#v to number", v)
+	case PropBool:
+		switch a := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if a == "" {
+				return true, nil
+			}
+			if b, err := strconv.ParseBool(a); err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("can't convert %#v to bool", v)
+	}
+	return v, nil
+}
+
+// 记录渲染过的组件
+func (r *Render) rendered(name string) {
+	r.logEffect(func(r *Render) { r.rendered(name) })
+	r.metaMu.Lock()
+	if r.meta.components == nil {
+		r.meta.components = map[string]bool{}
+	}
+	r.meta.components[name] = true
+	r.metaMu.Unlock()
+}
+
+// 记录渲染过的插槽
+func (r *Render) renderedSlot(name string) {
+	r.logEffect(func(r *Render) { r.renderedSlot(name) })
+	r.metaMu.Lock()
+	if r.meta.slots == nil {
+		r.meta.slots = map[string]bool{}
+	}
+	r.meta.slots[name] = true
+	r.metaMu.Unlock()
+}
+
+// 用于<textarea>/<pre>节点, 浏览器会忽略内容开头的一个换行, 所以内容以换行开头时需要多输出一个换行
+func keepLeadingNewline(r *Render, w Writer, f func(w Writer)) {
+	tw := r.NewWriter()
+	f(tw)
+	s := tw.Result()
+	if strings.HasPrefix(s, "\n") || strings.HasPrefix(s, "\r\n") {
+		w.WriteString("\n")
+	}
+	w.WriteString(s)
+}
+
+// 用于<title>节点, 收集标题并原样输出
+func collectTitle(r *Render, w Writer, f func(w Writer)) {
+	tw := r.NewWriter()
+	f(tw)
+	title := tw.Result()
+	r.setTitle(title)
+
+	w.WriteString(title)
+}
+
+func (r *Render) setTitle(title string) {
+	r.logEffect(func(r *Render) { r.setTitle(title) })
+	r.metaMu.Lock()
+	r.meta.title = title
+	r.metaMu.Unlock()
+}
+
+// 用于v-head, 收集节点而不输出, 相同key(非空)的节点只保留最后渲染的, 位置不变
+func collectHead(r *Render, key string, f func(w Writer)) {
+	hw := r.NewWriter()
+	f(hw)
+	r.addHead(key, hw.Result())
+}
+
+func (r *Render) addHead(key string, html string) {
+	r.logEffect(func(r *Render) { r.addHead(key, html) })
+	r.metaMu.Lock()
+	defer r.metaMu.Unlock()
+	if key != "" {
+		for i := range r.meta.head {
+			if r.meta.head[i].key == key {
+				r.meta.head[i].html = html
+				return
+			}
+		}
+	}
+	r.meta.head = append(r.meta.head, headItem{key: key, html: html})
+}
+
+// 返回渲染时由v-head收集的节点, 可以由调用方插入到<head>中
+func (r *Render) Head() string {
+	r.metaMu.Lock()
+	defer r.metaMu.Unlock()
+	return r.head()
+}
+
+func (r *Render) head() string {
+	var s strings.Builder
+	for _, h := range r.meta.head {
+		s.WriteString(h.html)
+	}
+	return s.String()
+}
+
+// 渲染注册的组件, 并只返回其中由selector选中的节点的html, 用于局部刷新.
+// selector: #id 或者 ref的值, 见ssrtool.SelectHtml
+func (r *Render) RenderPartial(name string, selector string, options *Options) (string, error) {
+	w := r.NewWriter()
+	r.Render(name, w, options)
+	return ssrtool.SelectHtml(w.Result(), selector)
+}
+
+// 渲染片段, 返回所有顶层节点拼接后的html, 用于局部刷新/ajax等不需要完整组件的场景.
+// 片段的模板可以有多个顶层节点, 不需要<template>包裹.
+// 和Render不同的是, 顶层节点不会继承props中的class/style/attr, props只作为模板中的变量使用.
+func (r *Render) RenderFragment(name string, props map[string]interface{}) (string, error) {
+	w := r.NewWriter()
+	r.Render(name, w, &Options{Props: NewProps(props), fragment: true})
+	return w.Result(), r.cancelErr
+}
+
+// 用来低成本生成一个Render
+// 注意: RenderCreator里所有变量在初始化之后都不应该被修改, 在Render中不应该有对其有副作用的操作.
+type RenderCreator struct {
+	Var *Scope // 存储静态变量与方法
+	// 注册的动态组件
+	Components map[string]ComponentFunc
+	// 指令
+	Directives map[string]DirectivesFunc
+	// 过滤器, 用于{{ value | filter }}语法
+	Filters map[string]FilterFunc
+	// 当需要渲染的组件没有注册时(如<component :is="name">中name是未注册或懒加载的组件), 会调用Placeholder渲染占位内容, 为nil时不输出任何内容
+	Placeholder PlaceholderFunc
+	// <component :is="name">中的name既不是注册的组件也不是html标签时, 渲染为FallbackTag节点(如div), 并添加data-component="name"属性, 子节点正常渲染.
+	// 默认为空: 调用Placeholder, 如果需要渲染一个兜底的组件, 可以在Placeholder中调用r.Render
+	FallbackTag string
+	// 为true时, <component :is="name">中的name既不是注册的组件也不是html标签时停止渲染, RenderContext/RenderFull会返回错误
+	StrictComponents bool
+	// 每个组件渲染完成后调用, 返回值会代替组件渲染的html, 可用于包裹或修改组件的输出(如在开发时添加耗时的注释).
+	// 子组件的输出会先经过OnComponentRendered, 再作为父组件html的一部分. 默认为nil: 不处理
+	// 注意: 组件中的异步内容(<async>)会在调用前等待完成
+	OnComponentRendered func(name string, html string) string
+	// 是否记录每个组件的渲染次数与累计耗时, 渲染完成后使用r.Stats()读取, 用于找出渲染慢的组件. 默认关闭
+	// 注意: 耗时不包括组件中异步渲染(<async>)的部分
+	Instrument bool
+	// 模板中同名变量的查找顺序, 为nil时使用DefaultResolveOrder. 不在其中的层不会被查找
+	ResolveOrder []ScopeLayer
+	// v-for最大循环次数, 超过的部分会被截断, 用于防止错误的数据导致渲染过慢. 默认为0: 不限制
+	MaxForIterations int
+	// 组件最大嵌套深度, 超过时不再渲染更深的组件, 用于防止错误的数据导致递归组件无限递归. 默认为0: 不限制
+	MaxDepth int
+	// 组件声明的prop类型, key是组件名, 见DeclareProps
+	PropTypes map[string]map[string]PropType
+	// 预估的渲染结果大小(字节), 渲染开始时会按此大小预分配输出缓冲, 减少大页面渲染时缓冲的多次扩容. 默认为0: 不预分配
+	// 只对默认的Writer(BufferWriter)生效
+	EstimatedSize int
+	// 用于输出渲染时的警告信息, 如v-for被截断, 默认为nil: 不输出
+	Warn func(format string, args ...interface{})
+	// 以下设置由生成器根据编译时的设置(见Compiler中的同名字段)生成, 使动态节点与静态节点的输出一致, 一般不需要修改
+
+	// 没有子元素的节点, 会渲染成<br/>这样的格式, 默认为html的void元素
+	VoidElements map[string]bool
+	// 是否按规范的顺序(id在最前, 其他按名字排序)输出属性, class/style始终在最前
+	CanonicalAttrs bool
+	// 是否按XHTML的格式输出bool属性(disabled="disabled")
+	XHTML bool
+	// 是否清理url属性(href/src/action), 包括v-bind="obj"与组件根节点继承的属性
+	SanitizeURL bool
+	// 是否将style提取到样式表中, 用生成的class代替style属性, 见Render.Styles
+	ExtractStyles bool
+
+	// 支持在指令里新生成一个Writer (用于异步渲染)
+	WriterCreator func() Writer
+	// v-memo的缓存, 在所有Render之间共享
+	memo *memoCache
+}
+
+func (c *RenderCreator) NewRender() *Render {
+	global := NewScope(c.Var)
+	global.order = c.ResolveOrder
+	return &Render{
+		Global:           global,
+		Store:            map[string]interface{}{},
+		components:       c.Components,
+		directives:       c.Directives,
+		filters:          c.Filters,
+		placeholder:      c.Placeholder,
+		maxForIterations: c.MaxForIterations,
+		maxDepth:         c.MaxDepth,
+		propTypes:        c.PropTypes,
+		estimatedSize:    c.EstimatedSize,
+		warn:             c.Warn,
+		voidElements:     c.VoidElements,
+		canonicalAttrs:   c.CanonicalAttrs,
+		xhtml:            c.XHTML,
+		sanitizeURL:      c.SanitizeURL,
+		componentHook:    c.OnComponentRendered,
+		instrument:       c.Instrument,
+		extractStyles:    c.ExtractStyles,
+		fallbackTag:      c.FallbackTag,
+		strictComponents: c.StrictComponents,
+		writerCreator:    c.WriterCreator,
+		memo:             c.memo,
+	}
+}
+
+// 注册组件, 可用于注册Go代码实现的组件(见Compiler.AddCodeComponent), 或者用于<component :is="name">
+// 同名的组件会被覆盖
+func (c *RenderCreator) Component(name string, f ComponentFunc) {
+	c.Components[name] = f
+}
+
+// 声明组件的prop类型, 渲染组件时会将prop转换为声明的类型(如"5"转为5), 没有传递的prop会使用默认值, 没有传递必须的prop时输出警告(见Warn).
+// 声明了的prop也可以使用静态属性传递: <counter count="5">, 此时它不会再作为attr渲染.
+// 默认不声明: 不做任何转换
+func (c *RenderCreator) DeclareProps(name string, types map[string]PropType) {
+	if c.PropTypes == nil {
+		c.PropTypes = map[string]map[string]PropType{}
+	}
+	c.PropTypes[name] = types
+}
+
+// 注册指令
+func (c *RenderCreator) Directive(name string, f DirectivesFunc) {
+	c.Directives[name] = f
+}
+
+// 注册过滤器, 同名的过滤器会被覆盖(包括内置过滤器)
+func (c *RenderCreator) Filter(name string, f FilterFunc) {
+	c.Filters[name] = f
+}
+
+// 注册方法
+func (c *RenderCreator) Func(name string, f Function) {
+	c.Var.Set(name, f)
+}
+
+// newRenderCreator 由代码生成器调用, 用作初始化(减少代码生成)
+func newRenderCreator() *RenderCreator {
+	return &RenderCreator{
+		Var:        builtinVar(),
+		Components: nil, // inject by generator
+		Directives: map[string]DirectivesFunc{
+			"v-show": func(r *Render, w Writer, binding DirectivesBinding, options *Options) {
+				if !rinterface.ToBool(binding.Value) {
+					if options.Style == nil {
+						options.Style = map[string]string{}
+					}
+					options.Style["display"] = "none"
+				}
+			},
+		},
+		Filters: map[string]FilterFunc{
+			// raw 将值标记为可信任的html, 在插值时不会被转义
+			"raw": func(r *Render, value interface{}, args ...interface{}) interface{} {
+				return RawHTML(interfaceToStr(value))
+			},
+			// date 格式化时间: {{ createdAt | date('2006-01-02') }}, 默认格式为2006-01-02 15:04:05
+			// 第二个参数是时区: {{ createdAt | date('2006-01-02', 'Asia/Shanghai') }}
+			"date": func(r *Render, value interface{}, args ...interface{}) interface{} {
+				layout := "2006-01-02 15:04:05"
+				if len(args) > 0 {
+					layout = interfaceToStr(args[0])
+				}
+				var loc *time.Location
+				if len(args) > 1 {
+					l, err := time.LoadLocation(interfaceToStr(args[1]))
+					if err != nil {
+						if r.warn != nil {
+							r.warn("date: %v", err)
+						}
+						l = time.UTC
+					}
+					loc = l
+				}
+				return formatDate(value, layout, loc)
+			},
+			// number 格式化数字(千分位): {{ price | number(2) }}, {{ price | number(2, 'de') }}
+			"number": func(r *Render, value interface{}, args ...interface{}) interface{} {
+				decimals := -1
+				if len(args) > 0 {
+					decimals = int(rinterface.ToInt(args[0]))
+				}
+				locale := ""
+				if len(args) > 1 {
+					locale = interfaceToStr(args[1])
+				}
+				return formatNumber(rinterface.ToFloat(value), decimals, locale)
+			},
+			// js 将值转义为可以安全放在js字符串字面量中的内容, 用于<script>中: var s = "{{ s | js }}"
+			// 会转义引号, 反斜杠, </script>中的<>, 换行与行分隔符(U+2028/U+2029)等
+			"js": func(r *Render, value interface{}, args ...interface{}) interface{} {
+				return RawHTML(template.JSEscapeString(interfaceToStr(value)))
+			},
+			// plural 根据数量选择单复数形式: {{ count | plural('item', 'items') }}
+			"plural": func(r *Render, value interface{}, args ...interface{}) interface{} {
+				return plural(value, args...)
+			},
+		},
+		VoidElements: voidElements,
+		Placeholder: func(r *Render, w Writer, name string, options *Options) {
+			w.WriteString(fmt.Sprintf("<p>not register component: %s</p>", name))
+		},
+		WriterCreator: func() Writer {
+			return NewBufferSpans()
+		},
+		memo: &memoCache{},
+	}
+}
+
+// 内置的全局变量与方法, 可以在任何组件中使用
+func builtinVar() *Scope {
+	s := NewScope(nil)
+	// plural(count, singular, plural) 根据数量选择单复数形式: {{ count }} {{ plural(count, 'item', 'items') }}
+	s.Set("plural", Function(func(r *Render, options *Options, args ...interface{}) interface{} {
+		if len(args) == 0 {
+			return ""
+		}
+		return plural(args[0], args[1:]...)
+	}))
+	// range([start, ]end[, step]) 生成从start(包含)到end(不包含)的整数, 用于v-for: v-for="n in range(1, 5)" 依次为1,2,3,4
+	s.Set("range", Function(func(r *Render, options *Options, args ...interface{}) interface{} {
+		return numRange(r.forLimit, args...)
+	}))
+	// $sanitizeURL 用于Compiler.SanitizeURL, 由编译器添加在:href/:src/:action的表达式外
+	s.Set("$sanitizeURL", Function(func(r *Render, options *Options, args ...interface{}) interface{} {
+		if len(args) == 0 || args[0] == nil {
+			return nil
+		}
+		return sanitizeURL(interfaceToStr(args[0]))
+	}))
+	return s
+}
+
+// 安全的url协议, 没有协议的url(相对路径)也是安全的
+var safeURLSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+	"ftp":    true,
+}
+
+// 不安全的url(如javascript:alert(1))会被替换为此值
+const unsafeURL = "about:invalid#unsafe"
+
+// 清理url: 拒绝不安全的协议(如javascript:), 并对空白, 控制字符, 非ASCII字符等进行百分号编码
+func sanitizeURL(u string) string {
+	u = strings.TrimSpace(u)
+
+	// 协议在第一个:之前, 并且:之前不能有/?#
+	if i := strings.IndexAny(u, ":/?#"); i != -1 && u[i] == ':' {
+		scheme := strings.ToLower(u[:i])
+		if !safeURLSchemes[scheme] {
+			return unsafeURL
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(u); i++ {
+		c := u[i]
+		if c <= ' ' || c >= 0x7f || c == '"' || c == '\'' || c == '<' || c == '>' || c == '\\' {
+			b.WriteString(fmt.Sprintf("%%%02X", c))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// 和python的range一样: range(end), range(start, end), range(start, end, step), 不包含end.
+// step为0时返回空. limit不为nil时用于限制生成的个数(见Render.forLimit), 在分配之前截断, 避免range(1e9)这样的参数占用大量内存
+func numRange(limit func(n int) int, args ...interface{}) []interface{} {
+	var start, end, step int64 = 0, 0, 1
+	switch len(args) {
+	case 0:
+		return nil
+	case 1:
+		end = rinterface.ToInt(args[0])
+	default:
+		start, end = rinterface.ToInt(args[0]), rinterface.ToInt(args[1])
+		if len(args) > 2 {
+			step = rinterface.ToInt(args[2])
+		}
+	}
+
+	var n int64
+	switch {
+	case step > 0 && end > start:
+		n = (end-start-1)/step + 1
+	case step < 0 && end < start:
+		n = (start-end-1)/-step + 1
+	}
+	if n == 0 {
+		return nil
+	}
+	if limit != nil {
+		n = int64(limit(int(n)))
+	}
+
+	s := make([]interface{}, n)
+	for i := range s {
+		s[i] = int(start + int64(i)*step)
+	}
+	return s
+}
+
+// 当count为1时返回单数形式forms[0], 否则返回复数形式forms[1]
+// 如果没有传递复数形式, 则使用单数形式+s
+func plural(count interface{}, forms ...interface{}) string {
+	if len(forms) == 0 {
+		return ""
+	}
+	singular := interfaceToStr(forms[0])
+	if rinterface.ToFloat(count) == 1 {
+		return singular
+	}
+	if len(forms) > 1 {
+		return interfaceToStr(forms[1])
+	}
+	return singular + "s"
+}
+
+// 将time.Time/*time.Time/unix时间戳(秒)按照layout格式化, 其他类型原样输出
+// loc为nil时time.Time使用自身的时区, 时间戳使用UTC, 结果不受服务器所在时区的影响
+func formatDate(value interface{}, layout string, loc *time.Location) string {
+	var t time.Time
+	switch a := value.(type) {
+	case time.Time:
+		t = a
+	case *time.Time:
+		if a == nil {
+			return ""
+		}
+		t = *a
+	case int, int64, int32, float64:
+		t = time.Unix(rinterface.ToInt(a), 0).UTC()
+	default:
+		return interfaceToStr(value)
+	}
+
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.Format(layout)
+}
+
+// 各地区数字的千分位与小数点分隔符
+var numberSeparators = map[string][2]string{
+	"":   {",", "."},
+	"en": {",", "."},
+	"zh": {",", "."},
+	"ja": {",", "."},
+	"de": {".", ","},
+	"es": {".", ","},
+	"it": {".", ","},
+	"fr": {"\u202f", ","},
+	"ru": {"\u00a0", ","},
+}
+
+// 格式化数字, 添加千分位分隔符
+// decimals: 保留的小数位数, 小于0时使用最少需要的位数
+// locale: 地区, 如en/de/fr, 支持en-US这样的写法
+// NaN与±Inf和js一样输出为NaN, Infinity与-Infinity
+func formatNumber(f float64, decimals int, locale string) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	sep, ok := numberSeparators[strings.ToLower(locale)]
+	if !ok {
+		sep, ok = numberSeparators[strings.ToLower(strings.SplitN(strings.Replace(locale, "_", "-", -1), "-", 2)[0])]
+		if !ok {
+			sep = numberSeparators[""]
+		}
+	}
+
+	s := strconv.FormatFloat(f, 'f', decimals, 64)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	intPart, fracPart := s, ""
+	if i := strings.Index(s, "."); i != -1 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteString("-")
+	}
+	for i, c := range intPart {
+		if i != 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(sep[0])
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteString(sep[1])
+		b.WriteString(fracPart)
+	}
+
+	return b.String()
+}
+
+type Store map[string]interface{}
+
+func (g Store) Get(key string) interface{} {
+	return g[key]
+}
+
+func (g Store) Set(key string, val interface{}) {
+	g[key] = val
+}
+
+type Global struct {
+	*Scope
+}
+
+func (p *Global) Func(name string, f Function) {
+	p.Scope.Set(name, f)
+}
+
+func (p *Global) Var(name string, v interface{}) {
+	p.Scope.Set(name, v)
+}
+
+// 实现在模板中调用函数语法: {{func(a)}}
+// options: 支持在options中获取变量(如inject的变量)
+// r: 从Render中获取全局变量(r.Global)
+// args: 从模板中传递的变量
+type Function func(r *Render, options *Options, args ...interface{}) interface{}
+
+type DirectivesBinding struct {
+	Value interface{}
+	Arg   string
+	Name  string
+}
+
+type DirectivesFunc func(r *Render, w Writer, b DirectivesBinding, options *Options)
+
+// 实现过滤器语法: {{ value | filter(args) }}
+// value: 管道左侧表达式的值
+// args: 过滤器的参数
+type FilterFunc func(r *Render, value interface{}, args ...interface{}) interface{}
+
+// 渲染未注册的组件的占位内容
+// name: 组件名字
+type PlaceholderFunc func(r *Render, w Writer, name string, options *Options)
+
+// RawHTML 表示已经转义过(或可信任)的html, interfaceToStr不会再次转义它
+type RawHTML string
+
+func emptyFunc(r *Render, options *Options, args ...interface{}) interface{} {
+	if len(args) != 0 {
+		return args[0]
+	}
+	return nil
+}
+
+// js中的作用域
+type Scope struct {
+	p      *Scope
+	values map[string]interface{}
+	// 从对象池获取时Render.asyncCount的值
+	asyncMark int32
+	// 计算属性, 只有r.Global上会有, 见Render.Computed
+	computed map[string]*computedProp
+	// 是否来自对象池(v-for/插槽的作用域), 这样的作用域在每次循环中会被复用, 其中的变量不能缓存, 见cachedGet
+	pooled bool
+	// Set的次数, 作用域中的变量被修改后缓存失效
+	sets int
+	// 作用域中的变量来自哪一层, 见ScopeLayer
+	layer ScopeLayer
+	// 查找变量的顺序, 为nil时使用默认顺序, 见RenderCreator.ResolveOrder
+	order []ScopeLayer
+}
+
+// 变量所在的层, 同名的变量按层的顺序查找, 见DefaultResolveOrder
+type ScopeLayer int
+
+const (
+	LayerLoop     ScopeLayer = iota // v-for/v-let/插槽等在模板中声明的变量
+	LayerProps                      // 组件的props
+	LayerComputed                   // 计算属性, 见Render.Computed
+	LayerGlobal                     // 全局变量与方法, 见RenderCreator.Var与Render.Func
+)
+
+func (l ScopeLayer) String() string {
+	switch l {
+	case LayerLoop:
+		return "loop"
+	case LayerProps:
+		return "props"
+	case LayerComputed:
+		return "computed"
+	case LayerGlobal:
+		return "global"
+	}
+	return fmt.Sprintf("ScopeLayer(%d)", int(l))
+}
+
+// 默认的变量查找顺序: 模板中声明的变量 > props > 计算属性 > 全局变量.
+// 同一层中内层(如嵌套的v-for)的变量优先
+var DefaultResolveOrder = []ScopeLayer{LayerLoop, LayerProps, LayerComputed, LayerGlobal}
+
+// 计算属性, 第一次读取时计算, 之后使用缓存的值
+type computedProp struct {
+	once sync.Once
+	f    func() interface{}
+	v    interface{}
+}
+
+func (c *computedProp) get() interface{} {
+	c.once.Do(func() {
+		c.v = c.f()
+	})
+	return c.v
+}
+
+func (s *Scope) ParentScope() *Scope {
+	return s.p
+}
+
+// 设置暂时只支持在当前作用域设置变量
+// 避免对上层变量造成副作用
+func (s *Scope) Set(k string, v interface{}) {
+	s.values[k] = v
+	s.sets++
+}
+
+// 查找作用域中的变量, 返回变量所在的map
+func (s *Scope) Find(k string) map[string]interface{} {
+	curr := s
+	for curr != nil {
+		if _, ok := curr.values[k]; ok {
+			return curr.values
+		}
+
+		curr = curr.p
+	}
+
+	return nil
+}
+
+// 新建全局变量的作用域
+func NewScope(parent *Scope) *Scope {
+	s := &Scope{
+		p:      parent,
+		values: map[string]interface{}{},
+		layer:  LayerGlobal,
+	}
+	if parent != nil {
+		s.order = parent.order
+	}
+	return s
+}
+
+// 继承全局作用域(r.Global)时是组件props的作用域, 否则是模板中声明的变量(如v-let)
+func extendScope(parent *Scope, data map[string]interface{}) *Scope {
+	s := &Scope{
+		p:      parent,
+		values: data,
+		layer:  LayerLoop,
+	}
+	if parent != nil {
+		s.order = parent.order
+		if parent.layer == LayerGlobal {
+			s.layer = LayerProps
+		}
+	}
+	return s
+}
+
+// v-for与插槽在每次循环/调用时都会创建作用域, 使用对象池复用它们以减少内存分配
+var scopePool = sync.Pool{
+	New: func() interface{} {
+		return &Scope{values: map[string]interface{}{}, pooled: true}
+	},
+}
+
+// 从对象池中获取一个作用域, 使用完毕后需要调用releaseScope归还
+func acquireScope(r *Render, parent *Scope) *Scope {
+	s := scopePool.Get().(*Scope)
+	s.p = parent
+	s.order = parent.order
+	s.asyncMark = atomic.LoadInt32(&r.asyncCount)
+	return s
+}
+
+// 清空并归还作用域
+// 渲染是同步的, 所以在归还时作用域不会再被使用, 除非在此期间启动了异步渲染(<async>), 这时作用域可能还在被使用, 不能归还.
+func releaseScope(r *Render, s *Scope) {
+	if atomic.LoadInt32(&r.asyncCount) != s.asyncMark {
+		return
+	}
+	for k := range s.values {
+		delete(s.values, k)
+	}
+	s.p = nil
+	scopePool.Put(s)
+}
+
+// 缓存路径表达式(如user.profile.avatar)结果的key
+type exprKey struct {
+	s    *Scope
+	sets int
+	path [4]string
+}
+
+// 一次渲染中最多缓存的表达式数量
+const exprCacheLimit = 10000
+
+// 和s.Get一样读取路径表达式的值, 结果在本次渲染中缓存, 用于Compiler.CacheExpr.
+// 只有路径的第一个key所在的作用域不是v-for/插槽的作用域时才会缓存, 作用域中的变量被修改(Set)后缓存失效.
+// 注意: 开启缓存时渲染过程中不应修改传入的数据(如修改props中的map).
+func cachedGet(r *Render, s *Scope, k ...string) interface{} {
+	if len(k) < 2 || len(k) > 4 || s.order != nil {
+		return s.Get(k...)
+	}
+	curr := s
+	for curr != nil {
+		if _, ok := curr.computed[k[0]]; ok {
+			break
+		}
+		if _, ok := curr.values[k[0]]; ok {
+			break
+		}
+		curr = curr.p
+	}
+	if curr == nil || curr.pooled {
+		return s.Get(k...)
+	}
+
+	key := exprKey{s: curr, sets: curr.sets}
+	copy(key.path[:], k)
+
+	r.exprMu.RLock()
+	v, ok := r.exprCache[key]
+	r.exprMu.RUnlock()
+	if ok {
+		return v
+	}
+
+	v = curr.Get(k...)
+	r.exprMu.Lock()
+	if r.exprCache == nil {
+		r.exprCache = map[exprKey]interface{}{}
+	}
+	if len(r.exprCache) < exprCacheLimit {
+		r.exprCache[key] = v
+	}
+	r.exprMu.Unlock()
+	return v
+}
+
+// 获取作用域中的变量
+// 会向上查找
+func (s *Scope) Get(k ...string) (v interface{}) {
+	var rootExist bool
+	var ok bool
+
+	if s.order != nil {
+		v, _, ok = s.Lookup(k[0])
+		if !ok {
+			return nil
+		}
+		return lookInterface(v, k[1:]...)
+	}
+
+	curr := s
+	for curr != nil {
+		// 计算属性与全局变量在同一个作用域(r.Global)中, 计算属性优先
+		if c, ok := curr.computed[k[0]]; ok {
+			return lookInterface(c.get(), k[1:]...)
+		}
+		v, rootExist, ok = shouldLookInterface(curr.values, k...)
+		// 如果root存在, 则说明就应该读取当前作用域, 否则向上层作用域查找
+		if rootExist {
+			if !ok {
+				return nil
+			} else {
+				return
+			}
+		}
+
+		curr = curr.p
+	}
+
+	return
+}
+
+// 查找变量k, 返回它的值与所在的层, 用于确认同名的变量中哪一个生效.
+// 查找顺序见DefaultResolveOrder与RenderCreator.ResolveOrder
+func (s *Scope) Lookup(k string) (v interface{}, layer ScopeLayer, ok bool) {
+	order := s.order
+	if order == nil {
+		// 默认顺序与作用域链的顺序一致, 只需要遍历一次
+		for curr := s; curr != nil; curr = curr.p {
+			if c, ok := curr.computed[k]; ok {
+				return c.get(), LayerComputed, true
+			}
+			if v, ok := curr.values[k]; ok {
+				return v, curr.layer, true
+			}
+		}
+		return nil, 0, false
+	}
+
+	for _, l := range order {
+		for curr := s; curr != nil; curr = curr.p {
+			if l == LayerComputed {
+				if c, ok := curr.computed[k]; ok {
+					return c.get(), l, true
+				}
+				continue
+			}
+			if curr.layer != l {
+				continue
+			}
+			if v, ok := curr.values[k]; ok {
+				return v, l, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+type Writer interface {
+	// 如果需要实现异步计算, 则需要将span存储, 在最后统一计算出string.
+	WriteSpan(Span)
+	// 如果是同步计算, 使用WriteString会将string结果直接存储或者拼接
+	WriteString(string)
+	Result() string
+}
+
+type Span interface {
+	Result() string
+}
+
+// 将多个Promise拼接为一个, 以减少内存与链的长度
+type BufferSpan struct {
+	s *strings.Builder
+}
+
+func (p *BufferSpan) Result() string {
+	return p.s.String()
+}
+
+func (p *BufferSpan) WriteString(s string) {
+	p.s.WriteString(s)
+}
+
+func NewBufferSpan(s string) Span {
+	var b strings.Builder
+	b.WriteString(s)
+	return &BufferSpan{
+		s: &b,
+	}
+}
+
+// buffer块, 同步计算
+type BufferWriter struct {
+	s *strings.Builder
+}
+
+func (p BufferWriter) WriteSpan(span Span) {
+	p.s.WriteString(span.Result())
+}
+
+func (p BufferWriter) WriteString(s string) {
+	p.s.WriteString(s)
+}
+
+func (p BufferWriter) Result() string {
+	return p.s.String()
+}
+
+func NewBufferSpans() Writer {
+	var b strings.Builder
+	return &BufferWriter{
+		s: &b,
+	}
+}
+
+// ListSpans将存储Span链表, 在最后计算结果, 可以实现并行计算.
+type ListSpans struct {
+	Value Span
+	Next  *ListSpans
+	Last  *ListSpans // 用于在append时提升速度
+}
+
+func (p *ListSpans) WriteSpans(s Writer) {
+	switch t := s.(type) {
+	case *ListSpans:
+		if t == nil || t.Value == nil {
+			return
+		}
+
+		if p.Value == nil {
+			if t.Next != nil {
+				// 跳过s的第一个元素, 将值存储到自己
+				// 注意: 如果s只有一个元素, 由于s.last存储的是s自己, p.Last也赋值为s.last的话, 如果跳过s, 就导致了p.Last存储了一个被抛弃(跳过)的元素, 当下次赋值p.Last.Next就会出错
+				p.Value = t.Value
+				p.Last = t.Last
+				p.Next = t.Next
+			} else {
+				// 如果s只有一个元素, 则抛弃s, 由p自己存储此元素
+				p.WriteSpan(t.Value)
+			}
+			return
+		}
+
+		if p.Last == nil || t.Last == nil {
+			panic("last不能为空")
+		}
+
+		// TODO 如果Last和t第一个元素可以合并, 则再合并一次
+		p.Last.Next = t
+		p.Last = t.Last
+	default:
+		panic("listSpan support Append listSpan only")
+	}
+}
+
+func (l *ListSpans) WriteString(s string) {
+	l.WriteSpan(NewBufferSpan(s))
+}
+
+func (p *ListSpans) WriteSpan(s Span) {
+	if p.Value == nil {
+		p.Value = s
+		p.Last = p
+		return
+	}
+
+	// 如果s是StringSpan并且p.Last也是StringSpan的话, 就将s的值附加到Last上
+	// 以减少链的长度
+	if ss, ok := s.(*BufferSpan); ok {
+		if ls, ok := p.Last.Value.(*BufferSpan); ok {
+			ls.WriteString(ss.Result())
+			return
+		}
+	}
+
+	last := &ListSpans{
+		Value: s,
+	}
+
+	p.Last.Next = last
+	p.Last = last
+}
+
+func (l *ListSpans) Result() string {
+	if l == nil || l.Value == nil {
+		return ""
+	}
+
+	b := strings.Builder{}
+
+	for cur := l; cur != nil; cur = cur.Next {
+		b.WriteString(cur.Value.Result())
+	}
+
+	return b.String()
+}
+
+func (l *ListSpans) Length() int {
+	if l == nil || l.Value == nil {
+		return 0
+	}
+
+	i := 0
+	for cur := l; cur != nil; cur = cur.Next {
+		i++
+	}
+
+	return i
+}
+
+func NewListSpans() Writer {
+	return &ListSpans{}
+}
+
+type ChanSpan struct {
+	c       chan string
+	getOnce sync.Once
+	setOnce sync.Once
+	r       string
+}
+
+func (p *ChanSpan) Result() string {
+	p.getOnce.Do(func() {
+		p.r = <-p.c
+	})
+	return p.r
+}
+
+func (p *ChanSpan) Done(s string) {
+	p.setOnce.Do(func() {
+		p.c <- s
+	})
+}
+
+func NewChanSpan() *ChanSpan {
+	return &ChanSpan{
+		c: make(chan string, 1),
+	}
+}
+
+// 自带的组件
+func _component(r *Render, w Writer, options *Options) {
+	val, ok := options.Props.Get("is")
+	if !ok {
+		return
+	}
+	is, ok := val.(string)
+	if !ok {
+		return
+	}
+
+	if _, ok := r.components[is]; !ok {
+		switch {
+		case htmlTags[is]:
+			// <component is="h2">渲染为html标签
+			options.Props = options.Props.without("is")
+			_tag(r, w, is, false, options)
+			return
+		case r.strictComponents:
+			r.fail(fmt.Errorf("unknown component: %s", is))
+			return
+		case r.fallbackTag != "":
+			options.Props = options.Props.without("is")
+			options.Attrs = append(Attributes{{Key: "data-component", Val: escape(is)}}, options.Attrs...)
+			_tag(r, w, r.fallbackTag, false, options)
+			return
+		}
+	}
+
+	r.Render(is, w, options)
+}
+
+// html标签, 用于<component :is>
+var htmlTags = func() map[string]bool {
+	m := map[string]bool{}
+	for _, t := range strings.Fields("a abbr address area article aside audio b bdi bdo blockquote br button canvas caption " +
+		"cite code col colgroup data datalist dd del details dfn dialog div dl dt em fieldset figcaption figure footer " +
+		"form h1 h2 h3 h4 h5 h6 header hr i iframe img input ins kbd label legend li main map mark menu meter nav " +
+		"noscript object ol optgroup option output p picture pre progress q rp rt ruby s samp section select small " +
+		"source span strong sub summary sup svg table tbody td textarea tfoot th thead time tr track u ul var video wbr") {
+		m[t] = true
+	}
+	return m
+}()
+
+func _template(r *Render, w Writer, options *Options) {
+	// exec directive
+	options.Directives.Exec(r, w, options)
+
+	options.Slots.Exec(w, "default", Props{})
+}
+
+// 内置组件Slot, 将渲染父级传递的slot.
+func _slot(r *Render, w Writer, options *Options) {
+	attr, _ := options.Attrs.Get("name")
+	name := attr.Val
+	if name == "" {
+		name = "default"
+	}
+	props := options.Props
+	injectSlotFunc, ok := options.P.Slots[name]
+	r.renderedSlot(name)
+
+	// 如果没有传递slot 则使用自身默认的slot
+	if !ok {
+		injectSlotFunc = options.Slots["default"]
+	}
+
+	injectSlotFunc.Exec(w, props)
+}
+
+func _async(r *Render, w Writer, options *Options) {
+	atomic.AddInt32(&r.asyncCount, 1)
+	s := NewChanSpan()
+	// 异步子节点计算
+	atomic.AddInt32(&r.asyncRunning, 1)
+	go func() {
+		defer atomic.AddInt32(&r.asyncRunning, -1)
+		w := r.NewWriter()
+		options.Slots.Exec(w, "default", Props{})
+		s.Done(w.Result())
+	}()
+
+	w.WriteSpan(s)
+
+	return
+}
+
+// 内置组件Teleport: <teleport to="#modal">
+// 子节点不会渲染在当前位置, 而是被收集起来, 在渲染完成后通过Render.Teleport(to)获取, 由调用方插入到对应的位置.
+func _teleport(r *Render, w Writer, options *Options) {
+	to := ""
+	if v, ok := options.Props.Get("to"); ok {
+		to = interfaceToStr(v)
+	} else if attr, ok := options.Attrs.Get("to"); ok {
+		to = attr.Val
+	}
+
+	tw := r.NewWriter()
+	options.Slots.Exec(tw, "default", Props{})
+	r.addTeleport(to, tw.Result())
+}
+
+func (r *Render) addTeleport(to string, html string) {
+	r.logEffect(func(r *Render) { r.addTeleport(to, html) })
+	r.teleportMu.Lock()
+	defer r.teleportMu.Unlock()
+	if r.teleports == nil {
+		r.teleports = map[string][]string{}
+	}
+	r.teleports[to] = append(r.teleports[to], html)
+}
+
+// 返回渲染时由<teleport to="to">收集的内容, 多个teleport的内容会按渲染顺序拼接
+func (r *Render) Teleport(to string) string {
+	r.teleportMu.Lock()
+	defer r.teleportMu.Unlock()
+	return strings.Join(r.teleports[to], "")
+}
+
+// v-memo缓存的最大条数, 超过时会清空缓存, 防止依赖的值过多(如使用了id)导致内存无限增长
+const memoCacheLimit = 10000
+
+type memoCache struct {
+	sync.RWMutex
+	m map[string]memoEntry
+}
+
+type memoEntry struct {
+	html string
+	// 渲染时的副作用, 使用缓存时需要在当前的Render上重新执行
+	effects []func(r *Render)
+}
+
+// 用于v-memo, 如果id与deps都相同则直接输出缓存的结果, 否则调用f渲染并缓存
+// 渲染时的副作用(收集的组件, 插槽, 标题, v-head, teleport与样式表)会被记录, 使用缓存时重新执行, 所以Render.RenderFull等的结果和不使用缓存时相同.
+// 输出依赖的Render.Nonce与Render.Locale也是缓存key的一部分. 组件的渲染统计(Render.Stats)在使用缓存时不会被记录.
+// deps无法序列化时不会缓存. 有异步渲染(<async>)正在进行时无法区分副作用来自哪里, 不会缓存, 但可以使用已有的缓存
+func memo(r *Render, id string, deps interface{}, w Writer, f func(w Writer)) {
+	if r.memo == nil {
+		f(w)
+		return
+	}
+	bs, err := json.Marshal(deps)
+	if err != nil {
+		f(w)
+		return
+	}
+	key := id + "\x00" + r.Nonce + "\x00" + r.Locale + "\x00" + string(bs)
+
+	r.memo.RLock()
+	e, ok := r.memo.m[key]
+	r.memo.RUnlock()
+	if ok {
+		for _, effect := range e.effects {
+			effect(r)
+		}
+		w.WriteString(e.html)
+		return
+	}
+
+	if atomic.LoadInt32(&r.asyncRunning) != 0 {
+		f(w)
+		return
+	}
+
+	r.effectsMu.Lock()
+	atomic.AddInt32(&r.recording, 1)
+	start := len(r.effects)
+	r.effectsMu.Unlock()
+
+	mw := r.NewWriter()
+	f(mw)
+	e.html = mw.Result()
+
+	r.effectsMu.Lock()
+	e.effects = append([]func(r *Render){}, r.effects[start:]...)
+	if atomic.AddInt32(&r.recording, -1) == 0 {
+		r.effects = nil
+	}
+	r.effectsMu.Unlock()
+
+	r.memo.Lock()
+	if r.memo.m == nil || len(r.memo.m) >= memoCacheLimit {
+		r.memo.m = map[string]memoEntry{}
+	}
+	r.memo.m[key] = e
+	r.memo.Unlock()
+
+	w.WriteString(e.html)
+}
+
+// 在v-memo渲染时记录副作用, 见memo
+func (r *Render) logEffect(f func(r *Render)) {
+	if atomic.LoadInt32(&r.recording) == 0 {
+		return
+	}
+	r.effectsMu.Lock()
+	if atomic.LoadInt32(&r.recording) != 0 {
+		r.effects = append(r.effects, f)
+	}
+	r.effectsMu.Unlock()
+}
+
+// 用于<script>与<style>节点, 生成CSP nonce属性
+func nonceAttr(r *Render) string {
+	if r.Nonce == "" {
+		return ""
+	}
+	return " nonce=\"" + escape(r.Nonce) + "\""
+}
+
+// 用于<html>节点, 根据r.Locale生成lang或dir属性(key), val是模板中静态的值(编译时已转义), 没有设置Locale时使用
+func localeAttr(r *Render, key, val string) string {
+	if r.Locale != "" {
+		if key == "lang" {
+			val = escape(strings.Replace(r.Locale, "_", "-", -1))
+		} else {
+			val = LocaleDir(r.Locale)
+		}
+	}
+	if val == "" {
+		return ""
+	}
+	return " " + key + "=\"" + val + "\""
+}
+
+// 从右向左书写的语言
+var rtlLanguages = map[string]bool{
+	"ar":  true, // 阿拉伯语
+	"arc": true,
+	"ckb": true, // 库尔德语(索拉尼)
+	"dv":  true,
+	"fa":  true, // 波斯语
+	"he":  true, // 希伯来语
+	"iw":  true,
+	"ps":  true,
+	"sd":  true,
+	"ug":  true,
+	"ur":  true, // 乌尔都语
+	"yi":  true,
+	"syr": true,
+}
+
+// 从右向左书写的文字, 用于locale中带有文字的情况(如az-Arab)
+var rtlScripts = map[string]bool{
+	"arab": true,
+	"hebr": true,
+	"thaa": true,
+	"syrc": true,
+}
+
+// 返回locale(如ar-EG, zh_CN)的书写方向: rtl或ltr
+// locale中带有文字时按文字判断(如az-Arab为rtl, ku-Latn为ltr), 否则按语言判断
+func LocaleDir(locale string) string {
+	parts := strings.FieldsFunc(strings.ToLower(locale), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	if len(parts) == 0 {
+		return "ltr"
+	}
+	for _, p := range parts[1:] {
+		if len(p) == 4 {
+			if rtlScripts[p] {
+				return "rtl"
+			}
+			return "ltr"
+		}
+	}
+	if rtlLanguages[parts[0]] {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// voidElements 没有子元素, 会渲染成 <br/> 这样的格式
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"keygen": true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// 动态tag
+// 何为动态tag:
+// - 每个组件的root层tag(attr受到上层传递的props影响)
+// - 有自己定义指令(自定义指令需要修改组件所有属性, 只能由动态tag实现)
+func _tag(r *Render, w Writer, tagName string, isRoot bool, options *Options) {
+	// exec directive
+	options.Directives.Exec(r, w, options)
+
+	var p *Options
+	if isRoot && options.P != nil && !options.P.fragment {
+		p = options.P
+	}
+
+	// attr
+	propsClass, propsStyle, props := bindClassStyle(options)
+	var attr string
+	if r.extractStyles {
+		attr = r.extractStyle(p, options.Class, propsClass, options.Style, propsStyle)
+	} else {
+		attr = mixinClass(p, options.Class, propsClass) +
+			mixinStyle(p, options.Style, propsStyle)
+	}
+	attrs := mixinAttrList(p, options.Attrs, props)
+	if r.sanitizeURL {
+		// 节点上的静态属性在最前, 是模板中写的, 不需要清理
+		sanitizeURLAttrs(attrs[len(options.Attrs):])
+	}
+	attr += formatAttrs(attrs, r.canonicalAttrs, r.xhtml)
+	if tagName == "script" || tagName == "style" {
+		attr += nonceAttr(r)
+	}
+
+	if r.voidElements[tagName] {
+		w.WriteString(fmt.Sprintf("<%s%s/>", tagName, attr))
+	} else {
+		w.WriteString(fmt.Sprintf("<%s%s>", tagName, attr))
+		options.Slots.Exec(w, "default", Props{})
+		w.WriteString(fmt.Sprintf("</%s>", tagName))
+	}
+
+	return
+}
+
+type Attribute struct {
+	Key, Val string
+}
+
+type Attributes []Attribute
+
+func (p Attributes) Get(key string) (Attribute, bool) {
+	for _, i := range p {
+		if i.Key == key {
+			return i, true
+		}
+	}
+
+	return Attribute{}, false
+}
+
+// 返回去掉key之后的Attributes, 不修改原来的Attributes
+func (p Attributes) omit(key string) Attributes {
+	a := make(Attributes, 0, len(p))
+	for _, i := range p {
+		if i.Key != key {
+			a = append(a, i)
+		}
+	}
+	return a
+}
+
+func (p *Attributes) Append(key string, val string) {
+	*p = append(*p, Attribute{Key: key, Val: val})
+}
+
+// 渲染组件需要的结构
+// tip: 此结构应该尽量的简单, 减少渲染时处理才能性能更好.
+type Options struct {
+	Props      Props                  // 本节点的数据(不包含class和style)
+	PropsClass interface{}            // :class
+	PropsStyle map[string]interface{} // :style
+	Attrs      Attributes             // 本节点静态的attrs (除去class和style)
+	Class      []string               // 本节点静态class
+	Style      map[string]string      // 本节点静态style
+	Slots      Slots                  // 当前组件所有的插槽代码(v-slot指令和默认的子节点), 支持多个不同名字的插槽, 如果没有名字则是"default"
+	// 有两种情况
+	// -  如果渲染的是元素（div等html元素），那么P是它所属的组件数据 ①
+	// -  如果渲染的是组件，那么P是它的父级组件数据 ②
+	// 在以下场景会用到 (后面的数字指的是属于上方的哪一种情况)
+	// - 渲染插槽. (根据name取到所属组件的slot) ①
+	// - 读取上层传递的PropsClass, 在root tag会读取上层的class等作用在自己身上. ①
+	// - Inject ①
+	// - Provide ①/②
+	P             *Options
+	Directives    directives // 多个指令
+	VonDirectives []vonDirective
+	// 组件模板中能够访问的所有值, 由Prototype+Props组成, 在指令中可以修改这个值达到声明变量的目的
+	// tips: 由于渲染顺序, 修改只会影响到子节点
+	Scope   *Scope
+	Provide map[string]interface{}
+
+	// 组件的嵌套深度, 见RenderCreator.MaxDepth
+	depth int
+	// 是否作为片段渲染, 片段的顶层节点不继承上层传递的class/style/attr, 见Render.RenderFragment
+	fragment bool
+}
+
+func (o *Options) SetProvide(d map[string]interface{}) {
+	if o.Provide == nil {
+		o.Provide = d
+	} else {
+		o.Provide = map[string]interface{}{}
+		for k, v := range d {
+			o.Provide[k] = v
+		}
+	}
+	return
+}
+
+// GetProvide会循环向上层查找Provide
+func (o *Options) GetProvide(k string) (v interface{}) {
+	// 向上查找
+	curr := o
+	for curr != nil {
+		if curr.Provide != nil {
+			if v, ok := curr.Provide[k]; ok {
+				return v
+			}
+		}
+
+		curr = curr.P
+	}
+
+	return nil
+}
+
+type directive struct {
+	Name  string
+	Value interface{}
+	Arg   string
+}
+
+type vonDirective struct {
+	Event string
+	Func  string
+	Args  []interface{}
+}
+
+type directives []directive
+
+func (ds directives) Exec(r *Render, w Writer, options *Options) {
+	for _, d := range ds {
+		if f, ok := r.directives[d.Name]; ok {
+			f(r, w, DirectivesBinding{
+				Value: d.Value,
+				Arg:   d.Arg,
+				Name:  d.Name,
+			}, options)
+		}
+	}
+}
+
+type Props struct {
+	orderKey []string               // 在生成attr时会用到顺序
+	data     map[string]interface{} // 存储map有利于快速存取
+}
+
+func (p *Props) Del(key string, value interface{}) {
+	for index, k := range p.orderKey {
+		if k == key {
+			p.orderKey = append(p.orderKey[:index], p.orderKey[index+1:]...)
+			break
+		}
+
+	}
+	delete(p.data, key)
+}
+
+func (p *Props) Set(key string, value interface{}) {
+	if p.data == nil {
+		p.data = map[string]interface{}{}
+	}
+
+	if _, ok := p.data[key]; ok {
+		p.data[key] = value
+	} else {
+		p.orderKey = append(p.orderKey, key)
+		p.data[key] = value
+	}
+}
+
+// 返回去掉key之后的Props, 不修改原来的Props
+func (p Props) without(key string) Props {
+	if _, ok := p.data[key]; !ok {
+		return p
+	}
+	n := Props{data: make(map[string]interface{}, len(p.data))}
+	for _, k := range p.orderKey {
+		if k != key {
+			n.orderKey = append(n.orderKey, k)
+			n.data[k] = p.data[k]
+		}
+	}
+	return n
+}
+
+func (p Props) Get(key string) (val interface{}, exist bool) {
+	if p.data == nil {
+		return
+	}
+
+	val, exist = p.data[key]
+	return
+}
+
+// Props可以转换为map, 方便在作用域中使用
+func (p Props) Map() map[string]interface{} {
+	return p.data
+}
+
+// 用于v-bind="obj"语法, 将obj中的所有字段作为props, 明确绑定的props会覆盖obj中的同名字段
+// obj可以是Props或者key为string的map, 其他类型会被忽略并输出警告
+func bindProps(r *Render, obj interface{}, props Props) Props {
+	p := Props{}
+	switch m := obj.(type) {
+	case nil:
+		return props
+	case map[string]interface{}:
+		for _, k := range getMapInterfaceKey(m) {
+			p.Set(k, m[k])
+		}
+	case Props:
+		for _, k := range m.orderKey {
+			p.Set(k, m.data[k])
+		}
+	case *Props:
+		if m == nil {
+			return props
+		}
+		for _, k := range m.orderKey {
+			p.Set(k, m.data[k])
+		}
+	default:
+		v := reflect.ValueOf(obj)
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			if r.warn != nil {
+				r.warn("v-bind: can't bind %T as props, want a map", obj)
+			}
+			return props
+		}
+		for _, k := range sortedMapKeys(v) {
+			p.Set(k.String(), v.MapIndex(k).Interface())
+		}
+	}
+
+	for _, k := range props.orderKey {
+		p.Set(k, props.data[k])
+	}
+	return p
+}
+
+// 在html节点上, :class/:style在编译期就已经被放在了PropsClass/PropsStyle里, 所以Props中的class/style只会来自v-bind="obj".
+// 它们需要和:class/:style一样处理(合并class, 合并style), 而不是当作普通的attr.
+func bindClassStyle(options *Options) (class interface{}, style map[string]interface{}, props Props) {
+	class, style, props = options.PropsClass, options.PropsStyle, options.Props
+
+	bindClass, hasClass := props.Get("class")
+	bindStyle, hasStyle := props.Get("style")
+	if !hasClass && !hasStyle {
+		return
+	}
+
+	if hasClass && bindClass != nil {
+		if class == nil {
+			class = bindClass
+		} else {
+			class = []interface{}{bindClass, class}
+		}
+	}
+
+	if hasStyle && bindStyle != nil {
+		// 明确绑定的:style会覆盖obj中的同名样式
+		style = map[string]interface{}{}
+		switch t := bindStyle.(type) {
+		case map[string]interface{}:
+			for k, v := range t {
+				style[k] = v
+			}
+		case string:
+			for _, item := range strings.Split(t, ";") {
+				kv := strings.SplitN(item, ":", 2)
+				if len(kv) == 2 {
+					style[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+				}
+			}
+		}
+		for k, v := range options.PropsStyle {
+			style[k] = v
+		}
+	}
+
+	props = Props{}
+	for _, k := range options.Props.orderKey {
+		if k == "class" || k == "style" {
+			continue
+		}
+		props.Set(k, options.Props.data[k])
+	}
+	return
+}
+
+func NewProps(data map[string]interface{}) Props {
+	return Props{
+		orderKey: getMapInterfaceKey(data),
+		data:     data,
+	}
+}
+
+// 能够被当成attr渲染出来的Props
+// 只在自定义组件的rootTag上使用
+func (p Props) CanBeAttr() Props {
+	htmlAttr := map[string]struct{}{
+		"id":  {},
+		"src": {},
+	}
+
+	a := Props{}
+	for _, k := range p.orderKey {
+		v := p.data[k]
+		if _, ok := htmlAttr[k]; ok {
+			a.Set(k, v)
+			continue
+		}
+
+		if strings.HasPrefix(k, "data-") {
+			a.Set(k, v)
+			continue
+		}
+
+		// 无障碍属性
+		if k == "role" || strings.HasPrefix(k, "aria-") {
+			a.Set(k, v)
+			continue
+		}
+	}
+	return a
+}
+
+type Slots map[string]NamedSlotFunc
+
+func (s Slots) Exec(w Writer, name string, slotProps Props) {
+	if s == nil {
+		return
+	}
+	if f, ok := s[name]; ok {
+		f(w, slotProps)
+		return
+	}
+
+	return
+}
+
+// 使用Go代码生成的html作为插槽内容, 用于在Go中组合组件(而不是在模板中), 如:
+// r.Render("layout", w, &Options{Slots: Slots{"default": SlotHtml(func() string { return body })}})
+// 注意: f返回的字符串不会被转义.
+func SlotHtml(f func() string) NamedSlotFunc {
+	return func(w Writer, slotProps Props) {
+		w.WriteString(f())
+	}
+}
+
+// 组件的render函数
+type ComponentFunc func(r *Render, w Writer, options *Options)
+
+// 用来生成slot的方法
+// 由于slot具有自己的作用域, 所以只能使用闭包实现(而不是字符串).
+type NamedSlotFunc func(w Writer, slotProps Props)
+
+func (f NamedSlotFunc) Exec(w Writer, slotProps Props) {
+	if f == nil {
+		return
+	}
+
+	f(w, slotProps)
+}
+
+// 混合动态和静态的标签, 主要是style/class需要混合
+// 顺序(style中后面的覆盖前面的): 本身的静态值 < 本身的props < 上层传递的静态值 < 上层传递的props
+// class中重复的值只会保留第一次出现的位置
+// todo) 如果style/class没有冲突, 则还可以优化
+// tip: 纯静态的class应该在编译时期就生成字符串, 而不应调用这个
+// classProps: 支持 obj, array, string
+// options: 上层组件的options
+func mixinClass(options *Options, staticClass []string, classProps interface{}) (str string) {
+	class := mixinClassList(options, staticClass, classProps)
+	if len(class) != 0 {
+		str = " class=\"" + strings.Join(class, " ") + "\""
+	}
+
+	return
+}
+
+func mixinClassList(options *Options, staticClass []string, classProps interface{}) (class []string) {
+	// 静态
+	for _, c := range staticClass {
+		class = appendClass(class, c)
+	}
+
+	// 本身的props
+	for _, c := range getClassFromProps(classProps) {
+		class = appendClass(class, c)
+	}
+
+	if options != nil {
+		// 上层传递的静态class
+		for _, c := range options.Class {
+			class = appendClass(class, c)
+		}
+
+		// 上层传递的props
+		if options.PropsClass != nil {
+			for _, c := range getClassFromProps(options.PropsClass) {
+				class = appendClass(class, c)
+			}
+		}
+	}
+
+	return
+}
+
+// 添加class, c中可以有多个用空格分隔的class, 已经存在的class会被忽略, 保证先出现的class顺序不变
+func appendClass(class []string, c string) []string {
+	for _, f := range strings.Fields(c) {
+		exist := false
+		for _, o := range class {
+			if o == f {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			class = append(class, f)
+		}
+	}
+	return class
+}
+
+// 构建style, 生成如style="color: red"的代码, 如果style代码为空 则只会返回空字符串
+func mixinStyle(options *Options, staticStyle map[string]string, styleProps map[string]interface{}) (str string) {
+	styleCode := genStyle(mixinStyleMap(options, staticStyle, styleProps, escape))
+	if styleCode != "" {
+		str = " style=\"" + styleCode + "\""
+	}
+
+	return
+}
+
+// esc用于转义props中的style, 输出为属性时是html转义, 提取到样式表时是css转义(见cssEscape)
+func mixinStyleMap(options *Options, staticStyle map[string]string, styleProps map[string]interface{}, esc func(string) string) map[string]string {
+	style := map[string]string{}
+
+	// 静态
+	for k, v := range staticStyle {
+		style[k] = v
+	}
+
+	// 当前props
+	ps := getStyleFromProps(styleProps, esc)
+	for k, v := range ps {
+		style[k] = v
+	}
+
+	if options != nil {
+		// 上层传递的静态style
+		for k, v := range options.Style {
+			style[k] = v
+		}
+
+		// 上层传递的props
+		if options.PropsStyle != nil {
+			ps := getStyleFromProps(options.PropsStyle, esc)
+			for k, v := range ps {
+				style[k] = v
+			}
+		}
+	}
+
+	return style
+}
+
+// 开启了RenderCreator.ExtractStyles时代替mixinClass与mixinStyle: style不再作为属性输出,
+// 而是放入本次渲染收集的样式表中(见Render.Styles), 节点上添加对应的class
+func (r *Render) extractStyle(options *Options, staticClass []string, classProps interface{}, staticStyle map[string]string, styleProps map[string]interface{}) string {
+	class := mixinClassList(options, staticClass, classProps)
+	if css := genStyle(mixinStyleMap(options, staticStyle, styleProps, cssEscape)); css != "" {
+		class = appendClass(class, r.styleClass(css))
+	}
+	if len(class) == 0 {
+		return ""
+	}
+	return " class=\"" + strings.Join(class, " ") + "\""
+}
+
+// 返回css对应的class名, 相同的css使用同一个class.
+// class名由css的hash生成, 不同的css的hash相同时, 后出现的css在名字后追加序号以区分
+func (r *Render) styleClass(css string) string {
+	h := fnv.New32a()
+	h.Write([]byte(css))
+	hash := fmt.Sprintf("s-%08x", h.Sum32())
+
+	r.logEffect(func(r *Render) { r.styleClass(css) })
+	r.stylesMu.Lock()
+	defer r.stylesMu.Unlock()
+	if r.styleNames == nil {
+		r.styleNames = map[string]string{}
+	}
+	name := hash
+	for i := 1; ; i++ {
+		old, ok := r.styleNames[name]
+		if !ok {
+			break
+		}
+		if old == css {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d", hash, i)
+	}
+	r.styleNames[name] = css
+	// 防止css中的</style>提前结束<style>节点
+	r.styles = append(r.styles, "."+name+"{"+strings.Replace(css, "<", "\\3c ", -1)+"}")
+	return name
+}
+
+// 返回开启了RenderCreator.ExtractStyles时收集的样式表, 按第一次出现的顺序排列, 由调用方放入<style>节点中
+// 如果使用了CSP, 需要在<style>节点上添加nonce, 见Render.Nonce
+func (r *Render) Styles() string {
+	r.stylesMu.Lock()
+	defer r.stylesMu.Unlock()
+	return strings.Join(r.styles, "\n")
+}
+
+// 生成除了style和class的attr
+func mixinAttr(options *Options, staticAttr []Attribute, propsAttr Props) string {
+	return genAttrWithSpace(mixinAttrList(options, staticAttr, propsAttr))
+}
+
+// 同mixinAttr, 但按规范的顺序输出属性, 见RenderCreator.CanonicalAttrs
+func mixinAttrCanonical(options *Options, staticAttr []Attribute, propsAttr Props) string {
+	return formatAttrs(mixinAttrList(options, staticAttr, propsAttr), true, false)
+}
+
+// 同mixinAttr, 但按XHTML的格式输出bool属性(disabled="disabled"), 见RenderCreator.XHTML
+func mixinAttrXHTML(options *Options, staticAttr []Attribute, propsAttr Props, canonical bool) string {
+	return formatAttrs(mixinAttrList(options, staticAttr, propsAttr), canonical, true)
+}
+
+// 输出属性, canonical为true时按规范的顺序输出, xhtml为true时按XHTML的格式输出bool属性
+func formatAttrs(attrs []Attribute, canonical, xhtml bool) string {
+	if canonical {
+		sort.SliceStable(attrs, func(i, j int) bool {
+			return canonicalAttrLess(attrs[i].Key, attrs[j].Key)
+		})
+	}
+	if xhtml {
+		for i, a := range attrs {
+			if a.Val == "" && boolAttr[a.Key] {
+				attrs[i].Val = a.Key
+			}
+		}
+	}
+	return genAttrWithSpace(attrs)
+}
+
+// 需要清理的url属性, 见RenderCreator.SanitizeURL
+var urlAttrs = map[string]bool{
+	"href":   true,
+	"src":    true,
+	"action": true,
+}
+
+func sanitizeURLAttrs(attrs []Attribute) {
+	for i, a := range attrs {
+		if urlAttrs[a.Key] {
+			attrs[i].Val = sanitizeURL(a.Val)
+		}
+	}
+}
+
+// 规范的属性顺序: id在最前, 其他按名字排序
+func canonicalAttrLess(a, b string) bool {
+	if a == "id" || b == "id" {
+		return a == "id" && b != "id"
+	}
+	return a < b
+}
+
+func mixinAttrList(options *Options, staticAttr []Attribute, propsAttr Props) []Attribute {
+	var attrs []Attribute
+
+	// 静态
+	attrs = append(attrs, staticAttr...)
+
+	// 当前props中的attr
+	attrs = append(attrs, getAttrFromProps(propsAttr)...)
+
+	if options != nil {
+		// 上层传递的静态style
+		attrs = append(attrs, options.Attrs...)
+
+		// 上层传递的props
+		if options.Props.data != nil {
+			attrs = append(attrs, getAttrFromProps(options.Props.CanBeAttr())...)
+		}
+	}
+
+	return attrs
+}
+
+func genAttrWithSpace(attrs []Attribute) string {
+	c := genAttr(attrs)
+	if c == "" {
+		return ""
+	}
+
+	return " " + c
+}
+
+func getSortedKey(m map[string]string) (keys []string) {
+	keys = make([]string, len(m))
+	index := 0
+	for k := range m {
+		keys[index] = k
+		index++
+	}
+	if len(m) < 2 {
+		return keys
+	}
+
+	sort.Strings(keys)
+
+	return
+}
+
+func getMapInterfaceKey(m map[string]interface{}) (keys []string) {
+	keys = make([]string, len(m))
+	index := 0
+	for k := range m {
+		keys[index] = k
+		index++
+	}
+	if len(m) < 2 {
+		return keys
+	}
+
+	sort.Strings(keys)
+
+	return
+}
+
+func genStyle(style map[string]string) string {
+	sortedKeys := getSortedKey(style)
+
+	var st strings.Builder
+	for _, k := range sortedKeys {
+		v := style[k]
+		if st.Len() != 0 {
+			st.WriteByte(' ')
+		}
+		st.WriteString(k + ": " + v + ";")
+	}
+
+	return st.String()
+}
+
+func genAttr(attr []Attribute) string {
+	var st strings.Builder
+	for _, k := range attr {
+		if st.Len() != 0 {
+			st.WriteByte(' ')
+		}
+		if k.Val != "" {
+			st.WriteString(k.Key + "=" + "\"" + k.Val + "\"")
+		} else {
+			st.WriteString(k.Key)
+		}
+	}
+
+	return st.String()
+}
+
+func getStyleFromProps(styleProps map[string]interface{}, esc func(string) string) map[string]string {
+	st := map[string]string{}
+	for k, v := range styleProps {
+		switch v := v.(type) {
+		case nil:
+			break
+		case string:
+			st[esc(k)] = esc(v)
+		default:
+			bs, _ := json.Marshal(v)
+			st[esc(k)] = esc(string(bs))
+		}
+	}
+	return st
+}
+
+// 转义样式表中的动态style, 防止其中的{};等字符结束当前规则而注入其他样式
+var cssEscaper = strings.NewReplacer(
+	"\\", "\\5c ",
+	"{", "\\7b ",
+	"}", "\\7d ",
+	";", "\\3b ",
+	"<", "\\3c ",
+	"/*", "/\\2a ",
+	"\n", "\\a ",
+	"\r", "\\d ",
+)
+
+func cssEscape(src string) string {
+	return cssEscaper.Replace(src)
+}
+
+// bool属性, 如果是 则当值不是true时不会渲染出此属性
+var boolAttr = map[string]bool{
+	"autofocus": true,
+	"autoplay":  true,
+	"async":     true,
+	"checked":   true,
+	"controls":  true,
+	"defer":     true,
+	"disabled":  true,
+	"hidden":    true,
+	"loop":      true,
+	"multiple":  true,
+	"muted":     true,
+	"open":      true,
+	"readonly":  true,
+	"required":  true,
+	"scoped":    true,
+	"selected":  true,
+}
+
+// 从props生成attr, 值为nil(如:title="show ? text : null")时不生成此attr, 和vue一致
+// 少数bool attr当value是空值时不生成attr, 值为true时只输出属性名(XHTML格式见mixinAttrXHTML)
+func getAttrFromProps(attrProps Props) []Attribute {
+	var st []Attribute
+	for _, key := range attrProps.orderKey {
+		value := attrProps.data[key]
+
+		isBoolAttr := boolAttr[key]
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" && isBoolAttr {
+				continue
+			}
+			st = append(st, Attribute{
+				Key: key,
+				Val: escape(v),
+			})
+		case bool:
+			if isBoolAttr {
+				if v {
+					st = append(st, Attribute{Key: key})
+				}
+				continue
+			}
+			bs, _ := json.Marshal(v)
+			st = append(st, Attribute{
+				Key: key,
+				Val: string(bs),
+			})
+		default:
+			bs, _ := json.Marshal(v)
+			st = append(st, Attribute{
+				Key: key,
+				Val: escape(string(bs)),
+			})
+		}
+	}
+	return st
+}
+
+// classProps: 支持 obj, array, string
+func getClassFromProps(classProps interface{}) []string {
+	if classProps == nil {
+		return nil
+	}
+	var cs []string
+	switch t := classProps.(type) {
+	case []string:
+		cs = t
+	case string:
+		cs = []string{t}
+	case map[string]interface{}:
+		var c []string
+		for k, v := range t {
+			if interfaceToBool(v) {
+				c = append(c, k)
+			}
+		}
+		sort.Strings(c)
+		cs = c
+	case []interface{}:
+		var c []string
+		for _, v := range t {
+			cc := getClassFromProps(v)
+			c = append(c, cc...)
+		}
+
+		cs = c
+	}
+
+	for i := range cs {
+		cs[i] = escape(cs[i])
+	}
+
+	return cs
+}
+
+func lookInterface(data interface{}, keys ...string) (desc interface{}) {
+	m, _, ok := shouldLookInterface(data, keys...)
+	if !ok {
+		return nil
+	}
+
+	return m
+}
+
+func lookInterfaceToSlice(data interface{}, key string) (desc []interface{}) {
+	m, _, ok := shouldLookInterface(data, key)
+	if !ok {
+		return nil
+	}
+
+	return interface2Slice(m)
+}
+
+// 扩展map, 实现作用域
+func extendMap(src map[string]interface{}, ext ...map[string]interface{}) (desc map[string]interface{}) {
+	desc = make(map[string]interface{}, len(src))
+	for k, v := range src {
+		desc[k] = v
+	}
+	for _, m := range ext {
+		for k, v := range m {
+			desc[k] = v
+		}
+	}
+	return desc
+}
+
+// 插值中的bool值输出为空字符串, 见Compiler.EmptyBool
+func emptyBool(v interface{}) interface{} {
+	if _, ok := v.(bool); ok {
+		return nil
+	}
+	return v
+}
+
+func interfaceToStr(s interface{}, escaped ...bool) (d string) {
+	switch a := s.(type) {
+	case nil:
+		return ""
+	case RawHTML:
+		// 已经是可信任的html, 不需要转义
+		return string(a)
+	case int, string, float64:
+		d = fmt.Sprintf("%v", a)
+	default:
+		bs, _ := json.Marshal(a)
+		d = string(bs)
+	}
+
+	if len(escaped) == 1 && escaped[0] {
+		d = escape(d)
+	}
+	return
+}
+
+// 字符串false,0 会被认定为false
+func interfaceToBool(s interface{}) (d bool) {
+	if s == nil {
+		return false
+	}
+	switch a := s.(type) {
+	case bool:
+		return a
+	case int, float64, float32, int8, int64, int32, int16:
+		return a != 0
+	case string:
+		return a != "" && a != "false" && a != "0"
+	default:
+		return true
+	}
+}
+
+func interfaceToFloat(s interface{}) (d float64) {
+	if s == nil {
+		return 0
+	}
+	switch a := s.(type) {
+	case int:
+		return float64(a)
+	case int32:
+		return float64(a)
+	case int64:
+		return float64(a)
+	case float64:
+		return a
+	case float32:
+		return float64(a)
+	default:
+		return 0
+	}
+}
+
+// 用来模拟js两个变量相加
+// 如果两个变量都是number, 则相加后也是number
+// 只有有一个不是number, 则都按字符串处理相加
+func interfaceAdd(a, b interface{}) interface{} {
+	an, ok := isNumber(a)
+	if !ok {
+		return interfaceToStr(a) + interfaceToStr(b)
+	}
+	bn, ok := isNumber(b)
+	if !ok {
+		return interfaceToStr(a) + interfaceToStr(b)
+	}
+
+	return an + bn
+}
+
+func interfaceLess(a, b interface{}) interface{} {
+	an, ok := isNumber(a)
+	if !ok {
+		return interfaceToStr(a) < interfaceToStr(b)
+	}
+	bn, ok := isNumber(b)
+	if !ok {
+		return interfaceToStr(a) < interfaceToStr(b)
+	}
+
+	return an < bn
+}
+
+func interfaceGreater(a, b interface{}) interface{} {
+	an, ok := isNumber(a)
+	if !ok {
+		return interfaceToStr(a) > interfaceToStr(b)
+	}
+	bn, ok := isNumber(b)
+	if !ok {
+		return interfaceToStr(a) > interfaceToStr(b)
+	}
+
+	return an > bn
+}
+
+func isNumber(s interface{}) (d float64, is bool) {
+	if s == nil {
+		return 0, false
+	}
+	switch a := s.(type) {
+	case int:
+		return float64(a), true
+	case int32:
+		return float64(a), true
+	case int64:
+		return float64(a), true
+	case float64:
+		return a, true
+	case float32:
+		return float64(a), true
+	default:
+		return 0, false
+	}
+}
+
+// 用于{{func(a)}}语法
+func interfaceToFunc(s interface{}) (d Function) {
+	if s == nil {
+		return emptyFunc
+	}
+
+	switch a := s.(type) {
+	case func(r *Render, options *Options, args ...interface{}) interface{}:
+		return a
+	case Function:
+		return a
+	default:
+		panic(a)
+	}
+}
+
+// 用于{{ value | filter }}语法, 没有注册的过滤器会原样返回value, 并输出警告(见RenderCreator.Warn)
+func execFilter(r *Render, name string, value interface{}, args ...interface{}) interface{} {
+	if f, ok := r.filters[name]; ok {
+		return f(r, value, args...)
+	}
+
+	if r.warn != nil {
+		r.warn("unknown filter %q", name)
+	}
+	return value
+}
+
+// 用于v-for, 将值转为数组, 并且数组长度不会超过r.maxForIterations
+// 先截断再转换, 超出的元素不会被复制
+func forSlice(r *Render, s interface{}) (d []interface{}) {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return interface2Slice(s)
+	}
+	n := r.forLimit(v.Len())
+	if n == v.Len() {
+		return interface2Slice(s)
+	}
+	if v.Kind() == reflect.Slice {
+		// 截断后的类型不变, 仍然可以使用interface2Slice中的快速路径
+		return interface2Slice(v.Slice(0, n).Interface())
+	}
+	d = make([]interface{}, n)
+	for i := range d {
+		d[i] = v.Index(i).Interface()
+	}
+	return
+}
+
+// 返回v-for在有n个元素时实际可以循环的次数, 超过r.maxForIterations时输出警告并截断
+func (r *Render) forLimit(n int) int {
+	if r.maxForIterations <= 0 || n <= r.maxForIterations {
+		return n
+	}
+	if r.warn != nil {
+		r.warn("v-for iterations truncated to MaxForIterations(%d)", r.maxForIterations)
+	}
+	return r.maxForIterations
+}
+
+// 用于v-for的迭代器函数, 每次调用yield渲染一个元素, 当yield返回false时应该停止迭代
+type ForIterator func(yield func(item interface{}) bool)
+
+// v-for.one: 下标从1开始, map的key不受影响
+func forRangeOne(r *Render, s interface{}, f func(index interface{}, item interface{})) {
+	if reflect.ValueOf(s).Kind() == reflect.Map {
+		forRange(r, s, f)
+		return
+	}
+	forRange(r, s, func(index interface{}, item interface{}) {
+		f(index.(int)+1, item)
+	})
+}
+
+// 用于v-for, 依次使用每个元素调用f
+// 除了数组之外, 还支持:
+//  - channel: 在接收到数据时渲染, 直到channel被关闭, 适合流式渲染.
+//    渲染被取消或超过r.maxForIterations时不再接收数据, 也不会关闭channel, 发送方需要自己处理取消(如select ctx.Done()), 否则会一直阻塞
+//  - ForIterator / func(func(interface{}) bool): 迭代器函数
+//  - map: index是map的key(同vue中的(value, key) in object), 按key排序后迭代, 保证每次渲染的顺序相同
+// 在渲染被取消或者超过r.maxForIterations时停止迭代
+func forRange(r *Render, s interface{}, f func(index interface{}, item interface{})) {
+	if v := reflect.ValueOf(s); v.Kind() == reflect.Map {
+		keys := sortedMapKeys(v)
+		keys = keys[:r.forLimit(len(keys))]
+		for _, k := range keys {
+			if r.canceled() {
+				break
+			}
+			f(k.Interface(), v.MapIndex(k).Interface())
+		}
+		return
+	}
+
+	var it ForIterator
+	switch a := s.(type) {
+	case ForIterator:
+		it = a
+	case func(func(interface{}) bool):
+		it = a
+	default:
+		if v := reflect.ValueOf(s); v.Kind() == reflect.Chan && v.Type().ChanDir()&reflect.RecvDir != 0 {
+			it = func(yield func(item interface{}) bool) {
+				cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: v}}
+				if r.done != nil {
+					cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(r.done)})
+				}
+				for {
+					chosen, item, ok := reflect.Select(cases)
+					if chosen != 0 {
+						// 等待数据时渲染被取消, 记录取消的原因
+						r.canceled()
+						return
+					}
+					if !ok || !yield(item.Interface()) {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	if it == nil {
+		for index, item := range forSlice(r, s) {
+			if r.canceled() {
+				break
+			}
+			f(index, item)
+		}
+		return
+	}
+
+	index := 0
+	it(func(item interface{}) bool {
+		if r.canceled() {
+			return false
+		}
+		// 迭代器不知道总长度, 当前是第index+1个元素
+		if r.forLimit(index+1) <= index {
+			return false
+		}
+		f(index, item)
+		index++
+		return true
+	})
+}
+
+// 将map的key排序: 数字按大小, 字符串按字典序, 其他类型按fmt.Sprint的结果
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return lessMapKey(keys[i], keys[j])
+	})
+	return keys
+}
+
+func lessMapKey(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a, b = a.Elem(), b.Elem()
+		// nil排在最前
+		if !a.IsValid() || !b.IsValid() {
+			return !a.IsValid() && b.IsValid()
+		}
+	}
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+func interface2Slice(s interface{}) (d []interface{}) {
+	switch a := s.(type) {
+	case []interface{}:
+		return a
+	case []map[string]interface{}:
+		d = make([]interface{}, len(a))
+		for i, v := range a {
+			d[i] = v
+		}
+	case []int:
+		d = make([]interface{}, len(a))
+		for i, v := range a {
+			d[i] = v
+		}
+	case []int64:
+		d = make([]interface{}, len(a))
+		for i, v := range a {
+			d[i] = v
+		}
+	case []int32:
+		d = make([]interface{}, len(a))
+		for i, v := range a {
+			d[i] = v
+		}
+	case []string:
+		d = make([]interface{}, len(a))
+		for i, v := range a {
+			d[i] = v
+		}
+	case []float64:
+		d = make([]interface{}, len(a))
+		for i, v := range a {
+			d[i] = v
+		}
+	default:
+		// 其他类型的数组, 如[]User
+		v := reflect.ValueOf(s)
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			d = make([]interface{}, v.Len())
+			for i := range d {
+				d[i] = v.Index(i).Interface()
+			}
+		}
+	}
+	return
+}
+
+// shouldLookInterface会返回interface(map[string]interface{})中指定的keys路径的值
+func shouldLookInterface(data interface{}, keys ...string) (desc interface{}, rootExist bool, exist bool) {
+	if len(keys) == 0 {
+		return data, true, true
+	}
+
+	currKey := keys[0]
+
+	switch data := data.(type) {
+	case map[string]interface{}:
+		// 对象
+		c, ok := data[currKey]
+		if !ok {
+			return
+		}
+		rootExist = true
+		desc, _, exist = shouldLookInterface(c, keys[1:]...)
+		return
+
+	case []interface{}:
+		// 数组
+		switch currKey {
+		case "length":
+			// length
+			return len(data), true, true
+		case "filter":
+			// filter(f), f是注册的方法, 参数为(item, index)
+			return sliceFilter(data), true, true
+		default:
+			// index
+			index, ok := strconv.ParseInt(currKey, 10, 64)
+			if ok != nil {
+				return
+			}
+
+			if int(index) >= len(data) || index < 0 {
+				return
+			}
+			return shouldLookInterface(data[index], keys[1:]...)
+		}
+	case string:
+		switch currKey {
+		case "length":
+			// length
+			return len(data), true, true
+		default:
+		}
+	default:
+		// 其他类型的数组, 如[]string, [][]int
+		v := reflect.ValueOf(data)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return
+		}
+		if currKey == "length" {
+			return v.Len(), true, true
+		}
+		index, err := strconv.ParseInt(currKey, 10, 64)
+		if err != nil || int(index) >= v.Len() || index < 0 {
+			return
+		}
+		return shouldLookInterface(v.Index(int(index)).Interface(), keys[1:]...)
+	}
+
+	return
+}
+
+// 实现数组的filter方法: list.filter(f)
+func sliceFilter(s []interface{}) Function {
+	return func(r *Render, options *Options, args ...interface{}) interface{} {
+		if len(args) == 0 {
+			return s
+		}
+		f := interfaceToFunc(args[0])
+
+		d := make([]interface{}, 0, len(s))
+		for i, v := range s {
+			if interfaceToBool(f(r, options, v, i)) {
+				d = append(d, v)
+			}
+		}
+		return d
+	}
+}
+
+func escape(src string) string {
+	return html.EscapeString(src)
+}
\ No newline at end of file
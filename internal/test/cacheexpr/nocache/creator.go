@@ -0,0 +1,12 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+
+package nocache
+
+func NewRenderCreator() *RenderCreator {
+	r := newRenderCreator()
+	r.Components = map[string]ComponentFunc{
+		"avatar-list": xx_avatarList,
+		"avatarList":  xx_avatarList,
+	}
+	return r
+}
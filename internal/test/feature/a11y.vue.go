@@ -0,0 +1,32 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:a8f884149a58c8dcbe4aaf36374470e6
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_a11y(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("a11y", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("a11y", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_myBtn(r, w, &Options{
+		Props: Props{orderKey: []string{"aria-pressed", "title"}, data: map[string]interface{}{"aria-pressed": scope.Get("pressed"), "title": scope.Get("title")}},
+		Attrs: []Attribute{
+			{Key: "role", Val: "button"}, {Key: "aria-label", Val: "Close"},
+		},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("x")
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
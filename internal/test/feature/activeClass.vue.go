@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:6563e622cafe24f08022366b901477b8
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_activeClass(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("activeClass", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("activeClass", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "nav", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("links"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("link", item)
+				w.WriteString("<a" + mixinClass(nil, nil, map[string]interface{}{"active": interfaceToFunc(scope.Get("isActive"))(r, options, scope.Get("link", "path")), "link": true}) + ">")
+				w.WriteString(interfaceToStr(scope.Get("link", "name"), true))
+				w.WriteString("</a>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0a59aed7785453fb01abd60568be5d6e
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_adjacent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("adjacent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("adjacent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("a"), true) + interfaceToStr(scope.Get("b"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
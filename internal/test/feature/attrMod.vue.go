@@ -0,0 +1,35 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ed6f8ec6b3275ced169fdf2270cee8b0
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_attrMod(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("attrMod", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("attrMod", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<span" + mixinAttr(nil, nil, Props{orderKey: []string{"data-x"}, data: map[string]interface{}{"data-x": scope.Get("val")}}) + ">a</span>")
+			xx_attrModChild(r, w, &Options{
+				Props: Props{orderKey: []string{"label"}, data: map[string]interface{}{"label": scope.Get("val")}},
+				Attrs: getAttrFromProps(Props{orderKey: []string{"data-x", "title"}, data: map[string]interface{}{"data-x": scope.Get("val"), "title": scope.Get("val")}}),
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
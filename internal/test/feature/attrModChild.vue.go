@@ -0,0 +1,30 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:6396da025eefdf93b078cb82a28e8337
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_attrModChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("attrModChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("attrModChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Class: []string{"child"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("label"), true) + "-" + interfaceToStr(scope.Get("title"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
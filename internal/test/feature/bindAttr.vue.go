@@ -0,0 +1,37 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:d831f2cc4af3c947ff26c03d3516160e
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_bindAttr(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("bindAttr", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("bindAttr", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			_tag(r, w, "button", false, &Options{
+				Props: bindProps(r, map[string]interface{}{"class": scope.Get("dynamicClass"), "style": scope.Get("dynamicStyle"), "disabled": scope.Get("isDisabled"), "title": scope.Get("title")}, Props{}),
+				Class: []string{"btn"},
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("ok")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:075fdc53c668f5b9bc55c7386ac11fac
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_bindChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("bindChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("bindChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("a"), true) + "-" + interfaceToStr(scope.Get("b"), true) + "-" + interfaceToStr(scope.Get("c"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,33 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:b89c844693225762facbd6c9b6c70c9f
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_bindObject(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("bindObject", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("bindObject", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_bindChild(r, w, &Options{
+				Props: bindProps(r, scope.Get("childProps"), Props{orderKey: []string{"c"}, data: map[string]interface{}{"c": "explicit"}}),
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
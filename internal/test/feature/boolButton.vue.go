@@ -0,0 +1,31 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:3bd2d65f37cd1aaeda17a33c99186c28
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_boolButton(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("boolButton", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("boolButton", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "button", true, &Options{
+		Props: Props{orderKey: []string{"disabled"}, data: map[string]interface{}{"disabled": scope.Get("disabled")}},
+		Class: []string{"b"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("x")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
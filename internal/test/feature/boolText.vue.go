@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:6b99fdd37d39728c38e8ed4a7411e6db
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_boolText(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("boolText", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("boolText", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("done: " + interfaceToStr(scope.Get("done"), true) + ", hidden: " + interfaceToStr(scope.Get("hidden"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
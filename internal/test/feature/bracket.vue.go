@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:07ad4e9ec0d5e3c3168419f63f85857b
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_bracket(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("bracket", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("bracket", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("items", "0", "name"), true) + "," + interfaceToStr(scope.Get("matrix", interfaceToStr(scope.Get("i")), interfaceToStr(scope.Get("j"))), true) + "," + interfaceToStr(scope.Get("matrix", "1", "0"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:f7d351968b23e5e73c2eed411e9309e7
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_classMerge(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("classMerge", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("classMerge", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p" + mixinClass(nil, []string{"btn", "primary"}, map[string]interface{}{"primary": scope.Get("isPrimary"), "active": scope.Get("isActive")}) + ">a</p><p" + mixinClass(nil, []string{"btn"}, []interface{}{"btn", scope.Get("extra"), "btn"}) + ">b</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,41 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ec104d768cafc159d22bc80ae414e026
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_codeComponent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("codeComponent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("codeComponent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			r.Render("codeCard", w, &Options{
+				Props: Props{orderKey: []string{"count"}, data: map[string]interface{}{"count": scope.Get("count")}},
+				Attrs: []Attribute{
+					{Key: "title", Val: "Go"},
+				},
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("<p>")
+					w.WriteString(interfaceToStr(scope.Get("body"), true))
+					w.WriteString("</p>")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,35 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:32e2a5e17d9b45f8275d0dd853143146
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_commentPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("commentPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("commentPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<!-- {{ secret }} -->")
+
+			if interfaceToBool(scope.Get("show")) {
+				w.WriteString("<p>a</p>")
+			} else {
+				w.WriteString("<!-- between --><p>b</p>")
+			}
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,44 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:5f0fbc3f316981298a76c1b38dbc7af7
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_computedPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("computedPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("computedPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>")
+			w.WriteString(interfaceToStr(scope.Get("fullName"), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(scope.Get("fullName"), true))
+			w.WriteString("</p>")
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("fullName", item)
+				w.WriteString("<span>")
+				w.WriteString(interfaceToStr(scope.Get("fullName"), true))
+				w.WriteString("</span>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,41 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:844313cc5b4e8f1ed52964b7dc79569e
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_condAttr(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("condAttr", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("condAttr", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<a" + mixinAttr(nil, []Attribute{
+				{Key: "href", Val: "/"},
+			}, Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": func() interface{} {
+				if interfaceToBool(scope.Get("show")) {
+					return scope.Get("text")
+				}
+				return nil
+			}()}}) + ">link</a><span" + mixinAttr(nil, nil, Props{orderKey: []string{"data-tip"}, data: map[string]interface{}{"data-tip": func() interface{} {
+				if interfaceToBool(scope.Get("show")) {
+					return scope.Get("text")
+				}
+				return scope.Get("undefined")
+			}()}}) + ">tip</span>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,42 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:e947dabb5aa7b1155862689c253eacb2
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_condSlot(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("condSlot", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("condSlot", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			if interfaceToBool(scope.Get("showHeader")) {
+				_slot(r, w, &Options{
+					Attrs: []Attribute{
+						{Key: "name", Val: "header"},
+					},
+					P:     options,
+					Scope: scope,
+				})
+			}
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,34 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:e10c0d5774c9dbafc8041c21bcea9443
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_condSlotParent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("condSlotParent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("condSlotParent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_condSlot(r, w, &Options{
+		Props: Props{orderKey: []string{"showHeader"}, data: map[string]interface{}{"showHeader": scope.Get("show")}},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>body</p>")
+		}, "header": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<h1>H</h1>")
+			releaseScope(r, scope)
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
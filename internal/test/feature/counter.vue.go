@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ef6c63fd3779da812961547c891f0f82
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_counter(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("counter", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("counter", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "span", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(interfaceAdd(scope.Get("count"), 1), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,45 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:9c237e6a4d45a7879ecd445d5a180d4d
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_counterParent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("counterParent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("counterParent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_counter(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "count", Val: "5"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			xx_counter(r, w, &Options{
+				Props: Props{orderKey: []string{"count"}, data: map[string]interface{}{"count": "7"}},
+				P:     options,
+				Scope: scope,
+			})
+			xx_counter(r, w, &Options{
+				Props: Props{orderKey: []string{"count"}, data: map[string]interface{}{"count": 9}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,175 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+
+package feature
+
+func NewRenderCreator() *RenderCreator {
+	r := newRenderCreator()
+	r.Components = map[string]ComponentFunc{
+		"a11y":               xx_a11y,
+		"active-class":       xx_activeClass,
+		"activeClass":        xx_activeClass,
+		"adjacent":           xx_adjacent,
+		"attr-mod":           xx_attrMod,
+		"attr-mod-child":     xx_attrModChild,
+		"attrMod":            xx_attrMod,
+		"attrModChild":       xx_attrModChild,
+		"bind-attr":          xx_bindAttr,
+		"bind-child":         xx_bindChild,
+		"bind-object":        xx_bindObject,
+		"bindAttr":           xx_bindAttr,
+		"bindChild":          xx_bindChild,
+		"bindObject":         xx_bindObject,
+		"bool-button":        xx_boolButton,
+		"bool-text":          xx_boolText,
+		"boolButton":         xx_boolButton,
+		"boolText":           xx_boolText,
+		"bracket":            xx_bracket,
+		"cancel":             xx_cancel,
+		"class-merge":        xx_classMerge,
+		"classMerge":         xx_classMerge,
+		"code-component":     xx_codeComponent,
+		"codeComponent":      xx_codeComponent,
+		"comment-page":       xx_commentPage,
+		"commentPage":        xx_commentPage,
+		"computed-page":      xx_computedPage,
+		"computedPage":       xx_computedPage,
+		"cond-attr":          xx_condAttr,
+		"cond-slot":          xx_condSlot,
+		"cond-slot-parent":   xx_condSlotParent,
+		"condAttr":           xx_condAttr,
+		"condSlot":           xx_condSlot,
+		"condSlotParent":     xx_condSlotParent,
+		"counter":            xx_counter,
+		"counter-parent":     xx_counterParent,
+		"counterParent":      xx_counterParent,
+		"doc-page":           xx_docPage,
+		"docPage":            xx_docPage,
+		"dynamic":            xx_dynamic,
+		"entity":             xx_entity,
+		"format":             xx_format,
+		"full-page":          xx_fullPage,
+		"fullPage":           xx_fullPage,
+		"head-child":         xx_headChild,
+		"head-page":          xx_headPage,
+		"headChild":          xx_headChild,
+		"headPage":           xx_headPage,
+		"if-group":           xx_ifGroup,
+		"if-root":            xx_ifRoot,
+		"if-root-child":      xx_ifRootChild,
+		"ifGroup":            xx_ifGroup,
+		"ifRoot":             xx_ifRoot,
+		"ifRootChild":        xx_ifRootChild,
+		"inner-wrap":         xx_innerWrap,
+		"innerWrap":          xx_innerWrap,
+		"js-embed":           xx_jsEmbed,
+		"jsEmbed":            xx_jsEmbed,
+		"layout":             xx_layout,
+		"leaf-item":          xx_leafItem,
+		"leaf-list":          xx_leafList,
+		"leaf-list-slot":     xx_leafListSlot,
+		"leafItem":           xx_leafItem,
+		"leafList":           xx_leafList,
+		"leafListSlot":       xx_leafListSlot,
+		"locale-page":        xx_localePage,
+		"localePage":         xx_localePage,
+		"loop-card":          xx_loopCard,
+		"loop-card-list":     xx_loopCardList,
+		"loopCard":           xx_loopCard,
+		"loopCardList":       xx_loopCardList,
+		"memo":               xx_memo,
+		"memo-child":         xx_memoChild,
+		"memo-effects":       xx_memoEffects,
+		"memo-style":         xx_memoStyle,
+		"memo-style-child":   xx_memoStyleChild,
+		"memoChild":          xx_memoChild,
+		"memoEffects":        xx_memoEffects,
+		"memoStyle":          xx_memoStyle,
+		"memoStyleChild":     xx_memoStyleChild,
+		"my-btn":             xx_myBtn,
+		"myBtn":              xx_myBtn,
+		"named-slots":        xx_namedSlots,
+		"named-slots-child":  xx_namedSlotsChild,
+		"namedSlots":         xx_namedSlots,
+		"namedSlotsChild":    xx_namedSlotsChild,
+		"nonce":              xx_nonce,
+		"num-attr":           xx_numAttr,
+		"numAttr":            xx_numAttr,
+		"outer-wrap":         xx_outerWrap,
+		"outerWrap":          xx_outerWrap,
+		"partial":            xx_partial,
+		"plain-slot":         xx_plainSlot,
+		"plainSlot":          xx_plainSlot,
+		"plural":             xx_plural,
+		"raw":                xx_raw,
+		"regions":            xx_regions,
+		"regions-merge":      xx_regionsMerge,
+		"regions-parent":     xx_regionsParent,
+		"regionsMerge":       xx_regionsMerge,
+		"regionsParent":      xx_regionsParent,
+		"render-func":        xx_renderFunc,
+		"renderFunc":         xx_renderFunc,
+		"resolve-order":      xx_resolveOrder,
+		"resolveOrder":       xx_resolveOrder,
+		"rows":               xx_rows,
+		"rows-template":      xx_rowsTemplate,
+		"rowsTemplate":       xx_rowsTemplate,
+		"sanitize-u-r-l":     xx_sanitizeURL,
+		"sanitizeURL":        xx_sanitizeURL,
+		"slot-row":           xx_slotRow,
+		"slot-row-parent":    xx_slotRowParent,
+		"slot-scope-missing": xx_slotScopeMissing,
+		"slot-template":      xx_slotTemplate,
+		"slotRow":            xx_slotRow,
+		"slotRowParent":      xx_slotRowParent,
+		"slotScopeMissing":   xx_slotScopeMissing,
+		"slotTemplate":       xx_slotTemplate,
+		"styled-card":        xx_styledCard,
+		"styled-card-parent": xx_styledCardParent,
+		"styledCard":         xx_styledCard,
+		"styledCardParent":   xx_styledCardParent,
+		"svg-title":          xx_svgTitle,
+		"svgTitle":           xx_svgTitle,
+		"teleport-page":      xx_teleportPage,
+		"teleportPage":       xx_teleportPage,
+		"textarea-form":      xx_textareaForm,
+		"textareaForm":       xx_textareaForm,
+		"titled":             xx_titled,
+		"titled-parent":      xx_titledParent,
+		"titledParent":       xx_titledParent,
+		"toggle":             xx_toggle,
+		"toggle-list":        xx_toggleList,
+		"toggleList":         xx_toggleList,
+		"tree":               xx_tree,
+		"v-for-chan":         xx_vForChan,
+		"v-for-exp":          xx_vForExp,
+		"v-for-limit":        xx_vForLimit,
+		"v-for-map":          xx_vForMap,
+		"v-for-nested":       xx_vForNested,
+		"v-for-one":          xx_vForOne,
+		"v-for-path":         xx_vForPath,
+		"v-for-pool":         xx_vForPool,
+		"v-for-range":        xx_vForRange,
+		"v-for-scope":        xx_vForScope,
+		"v-join":             xx_vJoin,
+		"v-let":              xx_vLet,
+		"v-text-override":    xx_vTextOverride,
+		"vForChan":           xx_vForChan,
+		"vForExp":            xx_vForExp,
+		"vForLimit":          xx_vForLimit,
+		"vForMap":            xx_vForMap,
+		"vForNested":         xx_vForNested,
+		"vForOne":            xx_vForOne,
+		"vForPath":           xx_vForPath,
+		"vForPool":           xx_vForPool,
+		"vForRange":          xx_vForRange,
+		"vForScope":          xx_vForScope,
+		"vJoin":              xx_vJoin,
+		"vLet":               xx_vLet,
+		"vTextOverride":      xx_vTextOverride,
+		"web-component":      xx_webComponent,
+		"webComponent":       xx_webComponent,
+		"wrap-page":          xx_wrapPage,
+		"wrapPage":           xx_wrapPage,
+	}
+	return r
+}
@@ -0,0 +1,42 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:96165b45c2d74de0320984d908e6d47d
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_docPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("docPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("docPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	w.WriteString("<!doctype html><html" + localeAttr(r, "lang", "en") + localeAttr(r, "dir", "") + "><head><meta charset=\"UTF-8\"/><title>")
+
+	collectTitle(r, w, func(w Writer) {
+		w.WriteString(interfaceToStr(scope.Get("title"), true))
+	})
+
+	w.WriteString("</title></head><body><h1 class=\"title\">")
+	w.WriteString(interfaceToStr(scope.Get("title"), true))
+	w.WriteString("</h1>")
+
+	forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+		scope := acquireScope(r, scope)
+		scope.Set("$index", index)
+		scope.Set("item", item)
+		w.WriteString("<p>")
+		w.WriteString(interfaceToStr(scope.Get("item"), true))
+		w.WriteString("</p>")
+		releaseScope(r, scope)
+	})
+
+	w.WriteString("</body></html>")
+	hookDone()
+	return
+}
@@ -0,0 +1,33 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:e79a55cd52f68ee44eeb58be9835d1c0
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_dynamic(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("dynamic", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("dynamic", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			_component(r, w, &Options{
+				Props: Props{orderKey: []string{"is", "title"}, data: map[string]interface{}{"is": scope.Get("name"), "title": scope.Get("title")}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,31 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:2eff5759fbb1eed711a38785bb48c0c1
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_entity(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("entity", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("entity", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>a&nbsp;b</p><p>Tom &amp; Jerry</p><p>© 2020</p><p>&lt;b&gt;</p><p>")
+			w.WriteString(interfaceToStr(scope.Get("msg"), true) + "&amp;" + interfaceToStr(interfaceToBool(scope.Get("a")) && interfaceToBool(scope.Get("b")), true))
+			w.WriteString("</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
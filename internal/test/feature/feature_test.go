@@ -0,0 +1,1615 @@
+// cd internal/test/feature
+// go-vue-ssr -src=./vue -to=./ -pkg=feature -code=code-card -keep-comments
+
+package feature
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func render(name string, props map[string]interface{}) string {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render(name, w, &Options{Props: NewProps(props)})
+	return w.Result()
+}
+
+func TestRawFilter(t *testing.T) {
+	html := render("raw", map[string]interface{}{
+		"trustedHtml": "<b>bold</b>",
+	})
+
+	want := `<div><p><b>bold</b></p><p>&lt;b&gt;bold&lt;/b&gt;</p></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestRenderPartial(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	html, err := r.RenderPartial("partial", "#main", &Options{Props: NewProps(map[string]interface{}{
+		"title": "title",
+		"list":  []interface{}{"a", "b"},
+	})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<div id="main"><p>a</p><p>b</p></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestDynamicComponentPlaceholder(t *testing.T) {
+	c := NewRenderCreator()
+	c.Placeholder = func(r *Render, w Writer, name string, options *Options) {
+		title, _ := options.Props.Get("title")
+		w.WriteString(fmt.Sprintf(`<div class="lazy" data-component="%s">%s</div>`, name, title))
+	}
+	r := c.NewRender()
+
+	w := r.NewWriter()
+	r.Render("dynamic", w, &Options{Props: NewProps(map[string]interface{}{
+		"name":  "unknownName",
+		"title": "loading",
+	})})
+	want := `<div><div class="lazy" data-component="unknownName">loading</div></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+
+	// 已注册的组件不会使用Placeholder
+	w = r.NewWriter()
+	r.Render("dynamic", w, &Options{Props: NewProps(map[string]interface{}{
+		"name":  "partial",
+		"title": "title",
+	})})
+	want = `<div><div><div id="header">title</div><div id="main"></div></div></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
+
+// 既不是注册的组件也不是html标签的:is会使用FallbackTag, html标签会直接渲染, StrictComponents时返回错误
+func TestDynamicComponentFallback(t *testing.T) {
+	c := NewRenderCreator()
+	c.FallbackTag = "div"
+
+	html := func(name string) string {
+		r := c.NewRender()
+		w := r.NewWriter()
+		r.Render("dynamic", w, &Options{Props: NewProps(map[string]interface{}{
+			"name":  name,
+			"title": "t",
+		})})
+		return w.Result()
+	}
+
+	if h, want := html("unknownName"), `<div><div data-component="unknownName" title="t"></div></div>`; h != want {
+		t.Fatalf("html = %s; want: %s", h, want)
+	}
+	if h, want := html("h2"), `<div><h2 title="t"></h2></div>`; h != want {
+		t.Fatalf("html = %s; want: %s", h, want)
+	}
+
+	c.StrictComponents = true
+	_, err := c.NewRender().RenderFull("dynamic", map[string]interface{}{"name": "unknownName"})
+	if err == nil || err.Error() != "unknown component: unknownName" {
+		t.Fatalf("want unknown component err, but: %v", err)
+	}
+}
+
+// 每个v-for的作用域只在自己的循环体内生效, 不会泄露到兄弟节点
+func TestVForScopeIsolation(t *testing.T) {
+	html := render("vForScope", map[string]interface{}{
+		"as": []interface{}{"a", "b"},
+		"bs": []interface{}{"c", "d", "e"},
+	})
+
+	want := `<div><p>0-a</p><p>1-b</p><span>0-c:</span><span>1-d:</span><span>2-e:</span><i></i></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestVLet(t *testing.T) {
+	c := NewRenderCreator()
+	calls := 0
+	c.Func("sum", func(r *Render, options *Options, args ...interface{}) interface{} {
+		calls++
+		return args[0].(int) + args[1].(int)
+	})
+	r := c.NewRender()
+
+	w := r.NewWriter()
+	r.Render("vLet", w, &Options{Props: NewProps(map[string]interface{}{
+		"a": 1,
+		"b": 2,
+	})})
+
+	want := `<div><p>3</p><p>3</p></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+	if calls != 1 {
+		t.Fatalf("sum calls = %d; want: 1", calls)
+	}
+}
+
+// <template v-slot>只会渲染子节点, 不会渲染出template标签
+func TestTemplateVSlot(t *testing.T) {
+	html := render("slotTemplate", nil)
+
+	want := `<div class="layout"><header><h1>Title</h1></header><main><p>body</p></main></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestMaxForIterations(t *testing.T) {
+	props := map[string]interface{}{
+		"list": []interface{}{1, 2, 3, 4, 5},
+	}
+
+	c := NewRenderCreator()
+	c.MaxForIterations = 2
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("vForLimit", w, &Options{Props: NewProps(props)})
+
+	want := `<ul><li>1</li><li>2</li></ul>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+	if len(warns) != 1 {
+		t.Fatalf("warns = %v; want 1 warning", warns)
+	}
+
+	// 默认不限制
+	html := render("vForLimit", props)
+	want = `<ul><li>1</li><li>2</li><li>3</li><li>4</li><li>5</li></ul>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestRenderContextCancel(t *testing.T) {
+	list := make([]interface{}, 10000)
+	for i := range list {
+		list[i] = i
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewRenderCreator()
+	ticks := 0
+	c.Func("tick", func(r *Render, options *Options, args ...interface{}) interface{} {
+		ticks++
+		// 渲染到一半时取消
+		if ticks == 3 {
+			cancel()
+		}
+		return args[0]
+	})
+	r := c.NewRender()
+	w := r.NewWriter()
+	err := r.RenderContext(ctx, "cancel", w, &Options{Props: NewProps(map[string]interface{}{
+		"list": list,
+	})})
+	if err != context.Canceled {
+		t.Fatalf("err = %v; want: %v", err, context.Canceled)
+	}
+
+	want := `<ul><li>0</li><li>1</li><li>2</li></ul>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+
+	// 没有被取消, 复用同一个Render时不会受到上一次取消的影响
+	w = r.NewWriter()
+	err = r.RenderContext(context.Background(), "cancel", w, &Options{Props: NewProps(map[string]interface{}{
+		"list": list[:2],
+	})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `<ul><li>0</li><li>1</li></ul>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
+
+// v-bind="obj"会将obj的字段全部作为props传递给组件, 明确绑定的props优先
+func TestVBindObjectOnComponent(t *testing.T) {
+	html := render("bindObject", map[string]interface{}{
+		"childProps": map[string]interface{}{
+			"a": 1,
+			"b": "two",
+			"c": "overridden",
+		},
+	})
+
+	want := `<div><p>1-two-explicit</p></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestHtmlEntity(t *testing.T) {
+	html := render("entity", map[string]interface{}{
+		"msg": "<i>",
+		"a":   true,
+		"b":   false,
+	})
+
+	// &nbsp;/&amp;/&lt;会重新编码, &copy;输出为字符
+	want := `<div><p>a&nbsp;b</p><p>Tom &amp; Jerry</p><p>© 2020</p><p>&lt;b&gt;</p><p>&lt;i&gt;&amp;false</p></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestVIfRootInheritAttr(t *testing.T) {
+	cases := []struct {
+		ok   bool
+		want string
+	}{
+		{true, `<div><p class="yes parent" style="color: red;">yes</p></div>`},
+		{false, `<div><span class="no parent" style="color: red;">no</span></div>`},
+	}
+
+	for _, c := range cases {
+		html := render("ifRoot", map[string]interface{}{
+			"ok": c.ok,
+		})
+		if html != c.want {
+			t.Fatalf("ok = %v, html = %s; want: %s", c.ok, html, c.want)
+		}
+	}
+}
+
+func TestVForChan(t *testing.T) {
+	items := make(chan string, 3)
+	items <- "a"
+	items <- "b"
+	items <- "c"
+	close(items)
+
+	html := render("vForChan", map[string]interface{}{
+		"items": items,
+	})
+
+	want := `<ul><li>0:a</li><li>1:b</li><li>2:c</li></ul>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	// 迭代器函数
+	html = render("vForChan", map[string]interface{}{
+		"items": ForIterator(func(yield func(item interface{}) bool) {
+			for _, v := range []int{1, 2, 3} {
+				if !yield(v) {
+					return
+				}
+			}
+		}),
+	})
+
+	want = `<ul><li>0:1</li><li>1:2</li><li>2:3</li></ul>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	cases := []struct {
+		count int
+		want  string
+	}{
+		{0, `<p>0 items, boxes</p>`},
+		{1, `<p>1 item, box</p>`},
+		{2, `<p>2 items, boxes</p>`},
+	}
+
+	for _, c := range cases {
+		html := render("plural", map[string]interface{}{
+			"count": c.count,
+		})
+		if html != c.want {
+			t.Fatalf("count = %d, html = %s; want: %s", c.count, html, c.want)
+		}
+	}
+}
+
+func TestDateNumberFilter(t *testing.T) {
+	props := map[string]interface{}{
+		"createdAt": time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC),
+		"price":     1234567.891,
+		"count":     -1234,
+	}
+
+	want := `<div><p>2020-03-04</p><p>2020-03-04 05:06:07</p><p>1,234,567.89</p><p>1.234.567,89</p><p>-1,234</p></div>`
+	html := render("format", props)
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	// 内置过滤器可以被覆盖
+	c := NewRenderCreator()
+	c.Filter("date", func(r *Render, value interface{}, args ...interface{}) interface{} {
+		return "today"
+	})
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("format", w, &Options{Props: NewProps(props)})
+
+	want = `<div><p>today</p><p>today</p><p>1,234,567.89</p><p>1.234.567,89</p><p>-1,234</p></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
+
+// 自定义的下标名字与默认的$index可以同时使用: $index来自没有自定义下标名字的外层循环
+func TestVForNestedIndex(t *testing.T) {
+	row := func(name string, cols ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": name, "cols": cols}
+	}
+	html := render("vForNested", map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{"rows": []interface{}{row("a", "x", "y"), row("b", "z")}},
+			map[string]interface{}{"rows": []interface{}{row("c", "w")}},
+		},
+	})
+
+	want := `<div>` +
+		`<section><ul><li>0-0-a-x-0</li><li>0-1-a-y-0</li></ul><ul><li>1-0-b-z-0</li></ul></section>` +
+		`<section><ul><li>0-0-c-w-1</li></ul></section>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// v-bind="obj"中的class/style与:class/:style一样处理, bool属性为false时不会渲染
+func TestVBindObjectOnElement(t *testing.T) {
+	html := render("bindAttr", map[string]interface{}{
+		"dynamicClass": map[string]interface{}{"active": true, "hidden": false},
+		"dynamicStyle": map[string]interface{}{"color": "red"},
+		"isDisabled":   true,
+		"title":        "ok",
+	})
+
+	want := `<div><button class="btn active" style="color: red;" disabled title="ok">ok</button></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	html = render("bindAttr", map[string]interface{}{
+		"dynamicClass": "a b",
+		"isDisabled":   false,
+		"title":        "ok",
+	})
+
+	want = `<div><button class="btn a b" title="ok">ok</button></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// <slot :item="data">会将item传递给父级的作用域插槽, 没有传递插槽时渲染后备内容
+func TestScopedSlotFallback(t *testing.T) {
+	html := render("slotRowParent", map[string]interface{}{
+		"list": []interface{}{"a", "b"},
+	})
+
+	want := `<div>` +
+		`<ul><li>row a</li><li>row b</li></ul>` +
+		`<ul><li>fallback a</li><li>fallback b</li></ul>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// html解析时不会将attr的key转为小写, 所以web component的大小写敏感的属性可以正常渲染
+func TestAttrCasing(t *testing.T) {
+	html := render("webComponent", map[string]interface{}{
+		"value": "v",
+	})
+
+	want := `<div><my-widget class="w" camelCaseAttr="static" dataValue="v"></my-widget></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// class对象的条件可以是方法调用
+func TestClassObjectMethodCall(t *testing.T) {
+	c := NewRenderCreator()
+	c.Func("isActive", func(r *Render, options *Options, args ...interface{}) interface{} {
+		return args[0] == "/b"
+	})
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("activeClass", w, &Options{Props: NewProps(map[string]interface{}{
+		"links": []interface{}{
+			map[string]interface{}{"path": "/a", "name": "A"},
+			map[string]interface{}{"path": "/b", "name": "B"},
+		},
+	})})
+
+	want := `<nav><a class="link">A</a><a class="active link">B</a></nav>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
+
+// 多个具名插槽的渲染顺序由子组件模板决定, 与传入的顺序无关, 多次渲染结果相同
+func TestNamedSlotsOrder(t *testing.T) {
+	want := `<div><header>H</header><main><p>default</p>B</main><footer>F</footer></div>`
+	for i := 0; i < 2; i++ {
+		html := render("namedSlots", nil)
+		if html != want {
+			t.Fatalf("html = %s; want: %s", html, want)
+		}
+	}
+}
+
+// v-for的数组可以是深层路径
+func TestVForNestedPath(t *testing.T) {
+	html := render("vForPath", map[string]interface{}{
+		"obj": map[string]interface{}{
+			"nested": map[string]interface{}{
+				"list": []interface{}{
+					map[string]interface{}{"name": "a"},
+					map[string]interface{}{"name": "b"},
+				},
+			},
+		},
+	})
+
+	want := `<ul><li>0:a</li><li>1:b</li></ul>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// v-for的数组可以是任意表达式: 数组的filter, 方法调用等
+func TestVForExpression(t *testing.T) {
+	c := NewRenderCreator()
+	c.Func("isActive", func(r *Render, options *Options, args ...interface{}) interface{} {
+		return args[0].(map[string]interface{})["active"]
+	})
+	c.Func("getItems", func(r *Render, options *Options, args ...interface{}) interface{} {
+		var items []string
+		for i := 0; i < args[0].(int); i++ {
+			items = append(items, fmt.Sprintf("i%d", i))
+		}
+		return items
+	})
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("vForExp", w, &Options{Props: NewProps(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "active": true},
+			map[string]interface{}{"name": "b", "active": false},
+			map[string]interface{}{"name": "c", "active": true},
+		},
+		// 任意类型的数组
+		"users": []uint{1, 2},
+	})})
+
+	want := `<div><p>a</p><p>c</p><i>i0</i><i>i1</i><b>1</b><b>2</b></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
+
+// 内联的<script>与<style>会添加CSP nonce, 其中的代码不会被编码
+func TestNonce(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	r.Nonce = "abc"
+	w := r.NewWriter()
+	r.Render("nonce", w, &Options{Props: NewProps(map[string]interface{}{
+		"show": true,
+	})})
+
+	want := `<div>` +
+		`<style nonce="abc">p > a { color: red }</style>` +
+		`<script nonce="abc">if (a < b && c) { run() }</script>` +
+		`<script src="/app.js" nonce="abc"></script>` +
+		`<p>a &amp; b</p>` +
+		`</div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+
+	// 默认没有nonce
+	html := render("nonce", nil)
+	want = `<div>` +
+		`<style>p > a { color: red }</style>` +
+		`<script>if (a < b && c) { run() }</script>` +
+		`<p>a &amp; b</p>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestAdjacentInterpolation(t *testing.T) {
+	html := render("adjacent", map[string]interface{}{
+		"a": "a",
+		"b": "b",
+	})
+
+	want := `<p>ab</p>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestSlotHtml(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("layout", w, &Options{
+		Slots: Slots{
+			"header":  SlotHtml(func() string { return "<h1>Go</h1>" }),
+			"default": SlotHtml(func() string { return fmt.Sprintf("<p>%d</p>", 1) }),
+		},
+	})
+
+	want := `<div class="layout"><header><h1>Go</h1></header><main><p>1</p></main></div>`
+	if html := w.Result(); html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestA11yAttrInherit(t *testing.T) {
+	html := render("a11y", map[string]interface{}{
+		"pressed": true,
+		"title":   "t",
+	})
+
+	want := `<button class="btn" role="button" aria-label="Close" aria-pressed="true">x</button>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestBracketIndex(t *testing.T) {
+	html := render("bracket", map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a"},
+		},
+		"matrix": [][]int{{1, 2}, {3, 4}},
+		"i":      0,
+		"j":      1,
+	})
+
+	want := `<p>a,2,3</p>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestConditionalSlot(t *testing.T) {
+	html := render("condSlotParent", map[string]interface{}{
+		"show": true,
+	})
+	want := `<div><h1>H</h1><p>body</p></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	html = render("condSlotParent", map[string]interface{}{
+		"show": false,
+	})
+	want = `<div><p>body</p></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestNumericAttr(t *testing.T) {
+	html := render("numAttr", map[string]interface{}{
+		"min": -10.25,
+	})
+
+	want := `<div><input type="number" tabindex="-1" step="0.5" min="-10.25" max="1e3"/><input type="number" tabindex="-1" step="0.5" min="-10.25" max="1000000"/></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestEstimatedSize(t *testing.T) {
+	for _, size := range []int{0, 1024} {
+		c := NewRenderCreator()
+		c.EstimatedSize = size
+		r := c.NewRender()
+		w := r.NewWriter()
+		r.Render("partial", w, &Options{Props: NewProps(map[string]interface{}{
+			"title": "title",
+			"list":  []interface{}{"a"},
+		})})
+
+		want := `<div><div id="header">title</div><div id="main"><p>a</p></div></div>`
+		if html := w.Result(); html != want {
+			t.Fatalf("html = %s; want: %s", html, want)
+		}
+
+		// 输出远小于预估的大小, 只有预分配时容量才会达到预估的大小
+		capacity := w.(*BufferWriter).s.Cap()
+		if size != 0 && capacity < size {
+			t.Fatalf("cap = %d; want preallocated %d", capacity, size)
+		}
+		if size == 0 && capacity >= 1024 {
+			t.Fatalf("cap = %d; want no preallocation", capacity)
+		}
+	}
+}
+
+// 没有插槽的组件不会生成Slots, 与带有(空)插槽的组件对比
+func BenchmarkLeafComponent(b *testing.B) {
+	list := make([]interface{}, 1000)
+	for i := range list {
+		list[i] = fmt.Sprintf("item-%d", i)
+	}
+	props := map[string]interface{}{
+		"list": list,
+	}
+
+	for _, name := range []string{"leafList", "leafListSlot"} {
+		b.Run(name, func(b *testing.B) {
+			c := NewRenderCreator()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r := c.NewRender()
+				w := r.NewWriter()
+				r.Render(name, w, &Options{Props: NewProps(props)})
+			}
+		})
+	}
+}
+
+func BenchmarkEstimatedSize(b *testing.B) {
+	list := make([]interface{}, 1000)
+	for i := range list {
+		list[i] = fmt.Sprintf("item-%d", i)
+	}
+	props := map[string]interface{}{
+		"title": "title",
+		"list":  list,
+	}
+
+	for _, size := range []int{0, 32 * 1024} {
+		b.Run(fmt.Sprintf("size-%d", size), func(b *testing.B) {
+			c := NewRenderCreator()
+			c.EstimatedSize = size
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r := c.NewRender()
+				w := r.NewWriter()
+				r.Render("partial", w, &Options{Props: NewProps(props)})
+			}
+		})
+	}
+}
+
+// v-for每次循环的作用域来自对象池, 上一次循环中声明的变量不应该泄露到下一次循环
+func TestVForScopePool(t *testing.T) {
+	c := NewRenderCreator()
+	c.Directive("v-mark", func(r *Render, w Writer, b DirectivesBinding, options *Options) {
+		if b.Value == true {
+			options.Scope.Set("marked", "*")
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		r := c.NewRender()
+		w := r.NewWriter()
+		r.Render("vForPool", w, &Options{Props: NewProps(map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{"name": "a", "mark": true},
+				map[string]interface{}{"name": "b", "mark": false},
+				map[string]interface{}{"name": "c", "mark": false},
+			},
+		})})
+
+		want := `<div><p>a*</p><p>b</p><p>c</p></div>`
+		if html := w.Result(); html != want {
+			t.Fatalf("html = %s; want: %s", html, want)
+		}
+	}
+}
+
+// v-for与插槽的作用域来自对象池, 用于观察每次渲染的分配次数
+func BenchmarkScopePool(b *testing.B) {
+	list := make([]interface{}, 1000)
+	for i := range list {
+		list[i] = map[string]interface{}{"name": fmt.Sprintf("item-%d", i), "mark": i%2 == 0}
+	}
+	props := map[string]interface{}{
+		"list": list,
+	}
+
+	for _, name := range []string{"vForPool", "slotRowParent"} {
+		b.Run(name, func(b *testing.B) {
+			c := NewRenderCreator()
+			c.Directive("v-mark", func(r *Render, w Writer, b DirectivesBinding, options *Options) {})
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r := c.NewRender()
+				w := r.NewWriter()
+				r.Render(name, w, &Options{Props: NewProps(props)})
+			}
+		})
+	}
+}
+
+func TestCodeComponent(t *testing.T) {
+	c := NewRenderCreator()
+	c.Component("codeCard", func(r *Render, w Writer, options *Options) {
+		count, _ := options.Props.Get("count")
+		attr, _ := options.Attrs.Get("title")
+		w.WriteString(fmt.Sprintf(`<section title="%s">%v:`, attr.Val, count))
+		options.Slots.Exec(w, "default", Props{})
+		w.WriteString("</section>")
+	})
+
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("codeComponent", w, &Options{Props: NewProps(map[string]interface{}{
+		"count": 2,
+		"body":  "body",
+	})})
+
+	want := `<div><section title="Go">2:<p>body</p></section></div>`
+	if html := w.Result(); html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestVRegion(t *testing.T) {
+	html := render("regionsParent", nil)
+
+	want := `<div class="page"><header><h1>Title</h1></header><main><article>Body</article></main><footer><p>Footer</p></footer></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestVRegionMerge(t *testing.T) {
+	html := render("regionsMerge", nil)
+
+	// 同名的region按顺序输出, 子组件的插槽不会传递给外层组件
+	want := `<div class="page"><header><h1>Title</h1></header><main></main><footer><script>var a = 1</script><script>var b = 2</script></footer></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestTeleport(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("teleportPage", w, &Options{Props: NewProps(map[string]interface{}{
+		"msg":    "hi",
+		"target": "#modal",
+	})})
+
+	want := `<div class="page"><p>main</p></div>`
+	if html := w.Result(); html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	want = `<div class="modal">hi</div><span>2</span>`
+	if html := r.Teleport("#modal"); html != want {
+		t.Fatalf("teleport = %s; want: %s", html, want)
+	}
+	if html := r.Teleport("#other"); html != "" {
+		t.Fatalf("teleport = %s; want empty", html)
+	}
+}
+
+// v-text/v-html会覆盖节点中的所有子节点
+func TestVTextOverrideChildren(t *testing.T) {
+	html := render("vTextOverride", map[string]interface{}{
+		"x": "<x>",
+		"h": "<b>h</b>",
+	})
+
+	want := `<section><div>&lt;x&gt;</div><div><b>h</b></div><p>&lt;x&gt;</p><b>h</b></section>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestJsFilter(t *testing.T) {
+	html := render("jsEmbed", map[string]interface{}{
+		"s": "</script><script>alert('x')</script>\"\\\n\u2028",
+	})
+
+	want := `<div><script>var s = "\u003C/script\u003E\u003Cscript\u003Ealert(\'x\')\u003C/script\u003E\"\\\u000A\u2028";</script></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestSanitizeURL(t *testing.T) {
+	html := render("sanitizeURL", map[string]interface{}{
+		"bad": "javascript:alert(1)",
+	})
+
+	want := `<div><a href="about:invalid#unsafe">bad</a><a href="javascript:alert(1)">raw</a></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestVMemo(t *testing.T) {
+	c := NewRenderCreator()
+	calls := 0
+	c.Func("heavy", func(r *Render, options *Options, args ...interface{}) interface{} {
+		calls++
+		return args[0]
+	})
+
+	renderList := func(list []interface{}) string {
+		r := c.NewRender()
+		w := r.NewWriter()
+		r.Render("memo", w, &Options{Props: NewProps(map[string]interface{}{
+			"list": list,
+		})})
+		return w.Result()
+	}
+
+	list := []interface{}{
+		map[string]interface{}{"id": 1, "name": "a", "done": false},
+		map[string]interface{}{"id": 2, "name": "b", "done": false},
+	}
+	want := `<ul><li>a</li><li>b</li></ul>`
+	for i := 0; i < 2; i++ {
+		if html := renderList(list); html != want {
+			t.Fatalf("html = %s; want: %s", html, want)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d; want: %d", calls, 2)
+	}
+
+	// 依赖变化时重新渲染, 没有写入依赖的name变化时依然使用缓存
+	list[0].(map[string]interface{})["done"] = true
+	list[1].(map[string]interface{})["name"] = "c"
+	if html := renderList(list); html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d; want: %d", calls, 3)
+	}
+}
+
+func TestRenderFull(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	res, err := r.RenderFull("fullPage", map[string]interface{}{
+		"name": "Bysir",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<div class="layout"><header><title>Bysir - Home</title></header><main><button class="btn">ok</button></main></div>`
+	if res.Body != want {
+		t.Fatalf("body = %s; want: %s", res.Body, want)
+	}
+	if res.Title != "Bysir - Home" {
+		t.Fatalf("title = %s; want: %s", res.Title, "Bysir - Home")
+	}
+	if got := strings.Join(res.Components, ","); got != "fullPage,layout,myBtn" {
+		t.Fatalf("components = %s; want: %s", got, "fullPage,layout,myBtn")
+	}
+	if got := strings.Join(res.Slots, ","); got != "default,header" {
+		t.Fatalf("slots = %s; want: %s", got, "default,header")
+	}
+	if res.Teleports["#modal"] != "<p>modal</p>" {
+		t.Fatalf("teleport = %s; want: %s", res.Teleports["#modal"], "<p>modal</p>")
+	}
+
+	// 复用Render时元信息不会累加
+	res, err = r.RenderFull("fullPage", map[string]interface{}{
+		"name": "Bysir",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Teleports["#modal"] != "<p>modal</p>" {
+		t.Fatalf("teleport = %s; want: %s", res.Teleports["#modal"], "<p>modal</p>")
+	}
+	if got := strings.Join(res.Components, ","); got != "fullPage,layout,myBtn" {
+		t.Fatalf("components = %s; want: %s", got, "fullPage,layout,myBtn")
+	}
+}
+
+func TestRenderFullSVGTitle(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	res, err := r.RenderFull("svgTitle", map[string]interface{}{
+		"name": "Bysir",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// svg中的<title>不是页面的标题
+	want := `<div><title>Bysir</title><svg><title>Close</title><path d="M0 0"></path></svg></div>`
+	if res.Body != want {
+		t.Fatalf("body = %s; want: %s", res.Body, want)
+	}
+	if res.Title != "Bysir" {
+		t.Fatalf("title = %s; want: %s", res.Title, "Bysir")
+	}
+}
+
+func TestVHead(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	res, err := r.RenderFull("headPage", map[string]interface{}{
+		"title": "Post",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<div><section><p>child</p></section></div>`
+	if res.Body != want {
+		t.Fatalf("body = %s; want: %s", res.Body, want)
+	}
+	wantHead := `<title>Post - Site</title><meta name="description" content="About Post"/><meta property="og:type" content="website"/>`
+	if res.Head != wantHead {
+		t.Fatalf("head = %s; want: %s", res.Head, wantHead)
+	}
+	if r.Head() != wantHead {
+		t.Fatalf("head = %s; want: %s", r.Head(), wantHead)
+	}
+	if res.Title != "Post - Site" {
+		t.Fatalf("title = %s; want: %s", res.Title, "Post - Site")
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	// 循环引用的数据会导致递归组件无限递归
+	node := map[string]interface{}{"name": "a"}
+	node["child"] = node
+
+	c := NewRenderCreator()
+	c.MaxDepth = 3
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("tree", w, &Options{Props: NewProps(map[string]interface{}{
+		"node": node,
+	})})
+
+	want := `<ul><li>a<ul><li>a<ul><li>a</li></ul></li></ul></li></ul>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+	if len(warns) != 1 || warns[0] != "component tree not rendered: depth 4 > MaxDepth(3)" {
+		t.Fatalf("warns = %v", warns)
+	}
+}
+
+func TestRenderFunc(t *testing.T) {
+	c := NewRenderCreator()
+	c.Func("sum", func(r *Render, options *Options, args ...interface{}) interface{} {
+		return "creator"
+	})
+
+	r := c.NewRender()
+	r.Func("sum", func(r *Render, options *Options, args ...interface{}) interface{} {
+		return args[0].(int) + args[1].(int)
+	})
+	w := r.NewWriter()
+	r.Render("renderFunc", w, &Options{Props: NewProps(map[string]interface{}{
+		"x": 40,
+	})})
+
+	want := `<p>42</p>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+
+	// 注册在Render上, 同一个Render再次渲染时仍然可用
+	w = r.NewWriter()
+	r.Render("renderFunc", w, &Options{Props: NewProps(map[string]interface{}{
+		"x": 40,
+	})})
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+
+	// 不影响其他Render
+	w = c.NewRender().NewWriter()
+	c.NewRender().Render("renderFunc", w, &Options{Props: NewProps(map[string]interface{}{
+		"x": 40,
+	})})
+	if w.Result() != `<p>creator</p>` {
+		t.Fatalf("html = %s; want: %s", w.Result(), `<p>creator</p>`)
+	}
+}
+
+// 计算属性在第一次读取时计算, 在本次渲染中只会计算一次, 局部变量会覆盖同名的计算属性
+func TestRenderComputed(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	r.Global.Set("firstName", "Ada")
+	r.Global.Set("lastName", "Lovelace")
+	calls := 0
+	r.Computed("fullName", func(r *Render) interface{} {
+		calls++
+		return fmt.Sprintf("%v %v", r.Global.Get("firstName"), r.Global.Get("lastName"))
+	})
+
+	w := r.NewWriter()
+	r.Render("computedPage", w, &Options{Props: NewProps(map[string]interface{}{
+		"list": []interface{}{"a"},
+	})})
+
+	want := `<div><p>Ada Lovelace</p><p>Ada Lovelace</p><span>a</span></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+	if calls != 1 {
+		t.Fatalf("computed called %d times; want: 1", calls)
+	}
+
+	// 再次渲染时不使用上一次渲染缓存的值
+	r.Global.Set("firstName", "Grace")
+	w = r.NewWriter()
+	r.Render("computedPage", w, &Options{Props: NewProps(map[string]interface{}{
+		"list": []interface{}{"a"},
+	})})
+	want = `<div><p>Grace Lovelace</p><p>Grace Lovelace</p><span>a</span></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+	if calls != 2 {
+		t.Fatalf("computed called %d times; want: 2", calls)
+	}
+}
+
+func TestRootClassStyleMerge(t *testing.T) {
+	html := render("styledCardParent", nil)
+
+	// 上层传递的值在后, 并覆盖root节点自身的style; 绑定的值覆盖静态的值
+	want := `<div><div class="card active shadow wide" style="border: 0; color: blue; margin: 8px; padding: 1px;">card</div></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestVForMap(t *testing.T) {
+	props := map[string]interface{}{
+		"names": map[string]interface{}{"c": 3, "a": 1, "b": 2, "B": 0},
+		"codes": map[int]string{10: "ten", 2: "two", -1: "minus", 100: "hundred"},
+	}
+
+	want := `<div><p>B=0</p><p>a=1</p><p>b=2</p><p>c=3</p><p>-1=minus</p><p>2=two</p><p>10=ten</p><p>100=hundred</p></div>`
+	// map的迭代顺序是随机的, 多次渲染确认输出稳定
+	for i := 0; i < 20; i++ {
+		html := render("vForMap", props)
+		if html != want {
+			t.Fatalf("html = %s; want: %s", html, want)
+		}
+	}
+}
+
+func TestDeclareProps(t *testing.T) {
+	// 没有声明时不转换
+	html := render("counterParent", nil)
+	want := `<div><span count="5">1</span><span>71</span><span>10</span></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	c := NewRenderCreator()
+	c.DeclareProps("counter", map[string]PropType{
+		"count": {Kind: PropNumber},
+		"label": {Kind: PropString, Required: true},
+	})
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("counterParent", w, &Options{})
+
+	want = `<div><span>6</span><span>8</span><span>10</span></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+	if len(warns) != 3 || warns[0] != `component counter: missing required prop "label"` {
+		t.Fatalf("warns = %v", warns)
+	}
+}
+
+func TestPropDefault(t *testing.T) {
+	c := NewRenderCreator()
+	c.DeclareProps("titled", map[string]PropType{
+		"title": {Kind: PropString, Default: "Untitled"},
+	})
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("titledParent", w, &Options{Props: NewProps(map[string]interface{}{
+		"name": "Bysir",
+	})})
+
+	want := `<div><h1>Untitled</h1><h1>Hello</h1><h1>Bysir</h1></div>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
+
+func TestSlotFallThrough(t *testing.T) {
+	html := render("wrapPage", map[string]interface{}{
+		"msg": "content",
+	})
+
+	want := `<div>` +
+		`<section class="outer"><div class="inner"><p>content</p></div></section>` +
+		`<section class="outer"><div class="inner"><div class="inner">content</div></div></section>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestLeafComponent(t *testing.T) {
+	props := map[string]interface{}{
+		"list": []interface{}{"a", "b"},
+	}
+	want := `<ul><li>a</li><li>b</li></ul>`
+	for _, name := range []string{"leafList", "leafListSlot"} {
+		if html := render(name, props); html != want {
+			t.Fatalf("%s: html = %s; want: %s", name, html, want)
+		}
+	}
+}
+
+func TestTextareaNewline(t *testing.T) {
+	html := render("textareaForm", map[string]interface{}{
+		"value": "\nline1\nline2 <b>",
+	})
+
+	// 浏览器会忽略开头的一个换行, 所以值以换行开头时会多输出一个换行
+	want := "<form>" +
+		"<textarea name=\"a\">\n\nline1\nline2 &lt;b&gt;</textarea>" +
+		"<textarea name=\"b\">first &amp; \nline1\nline2 &lt;b&gt;</textarea>" +
+		"<pre>\n\nline1\nline2 &lt;b&gt;</pre>" +
+		"</form>"
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// :foo.attr总是渲染为attr, 作用在组件上时不会作为prop传递, 而是渲染在组件的根节点上
+func TestVBindAttrModifier(t *testing.T) {
+	html := render("attrMod", map[string]interface{}{
+		"val": "v<1>",
+	})
+
+	want := `<div>` +
+		`<span data-x="v&lt;1&gt;">a</span>` +
+		`<p class="child" data-x="v&lt;1&gt;" title="v&lt;1&gt;">v&lt;1&gt;-</p>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// v-for中传递给组件的prop在每次循环中计算, 子组件拿到的是当次循环的item而不是最后一个
+func TestVForComponentItem(t *testing.T) {
+	html := render("loopCardList", map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "price": 1},
+			map[string]interface{}{"name": "b", "price": 2},
+			map[string]interface{}{"name": "c", "price": 3},
+		},
+	})
+
+	want := `<div>` +
+		`<div class="card">a:1</div>` +
+		`<div class="card">b:2</div>` +
+		`<div class="card">c:3</div>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// 保留的注释原样输出, 其中的{{secret}}不会被计算, 注释也不会打断v-if与v-else, 并保持源码中的顺序
+func TestKeepComments(t *testing.T) {
+	html := render("commentPage", map[string]interface{}{
+		"secret": "password",
+		"show":   false,
+	})
+
+	want := `<div><!-- {{ secret }} --><!-- between --><p>b</p></div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+
+	// v-if成立时, 与v-else之间的注释和v-else一起被跳过
+	html = render("commentPage", map[string]interface{}{
+		"secret": "password",
+		"show":   true,
+	})
+
+	want = `<div><!-- {{ secret }} --><p>a</p></div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// v-for.one的下标从1开始, 默认从0开始, 遍历map时key不受影响
+func TestVForOneBased(t *testing.T) {
+	html := render("vForOne", map[string]interface{}{
+		"list": []interface{}{"a", "b"},
+		"obj":  map[string]interface{}{"x": 1},
+	})
+
+	want := `<div>` +
+		`<p><span>1:a;</span><span>2:b;</span></p>` +
+		`<p><span>0:a;</span><span>1:b;</span></p>` +
+		`<p><span>1;</span><span>2;</span></p>` +
+		`<p><span>x=1;</span></p>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// 静态class总是渲染, 动态class追加在后面, 重复的class只保留第一次出现的位置
+func TestClassMergeDedup(t *testing.T) {
+	html := render("classMerge", map[string]interface{}{
+		"isPrimary": true,
+		"isActive":  true,
+		"extra":     "wide btn",
+	})
+
+	want := `<div>` +
+		`<p class="btn primary active">a</p>` +
+		`<p class="btn wide">b</p>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// 整个html页面也可以作为组件渲染, 输出包含doctype/head/body的完整文档
+func TestFullDocument(t *testing.T) {
+	html := render("docPage", map[string]interface{}{
+		"title": "Hi",
+		"list":  []interface{}{"a", "b"},
+	})
+
+	want := `<!doctype html>` +
+		`<html lang="en">` +
+		`<head><meta charset="UTF-8"/><title>Hi</title></head>` +
+		`<body><h1 class="title">Hi</h1><p>a</p><p>b</p></body>` +
+		`</html>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// v-join在每次循环之间输出分隔符, 最后没有多余的分隔符, 被v-if跳过的循环不输出分隔符
+func TestVJoin(t *testing.T) {
+	html := render("vJoin", map[string]interface{}{
+		"list": []interface{}{"a", "b", "c"},
+		"rows": []interface{}{[]interface{}{1, 2}, []interface{}{3}},
+	})
+
+	want := `<div>` +
+		`<p><span>a</span>, <span>b</span>, <span>c</span></p>` +
+		`<p>a &amp; b &amp; c</p>` +
+		`<p><b>a</b>|<b>c</b></p>` +
+		`<p><i>1,2</i>; <i>3</i></p>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// <template v-if>中的多个子节点作为一组被条件渲染
+func TestTemplateVIfGroup(t *testing.T) {
+	html := render("ifGroup", map[string]interface{}{"show": true})
+	want := `<div><a href="#a">a</a><b>b</b><span>mid</span><em>x</em><u>y</u></div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+
+	html = render("ifGroup", map[string]interface{}{"show": false})
+	// 没有v-else时整组都不渲染
+	want = `<div><span>mid</span><i>none</i></div>`
+	if html != want {
+		t.Fatalf("html = %q; want: %q", html, want)
+	}
+}
+
+// 多个顶层节点的片段不需要<template>包裹, 渲染时不继承props中的属性
+func TestRenderFragment(t *testing.T) {
+	props := map[string]interface{}{"a": "1", "b": "2", "c": "3", "id": "x", "class": "c"}
+
+	html, err := NewRenderCreator().NewRender().RenderFragment("rows", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<tr class="row"><td>1</td></tr><tr><td>2</td></tr><tr><td>3</td></tr>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	// 作为组件渲染时, <template>下的每个节点都会继承上层的属性, 作为片段则不会
+	html, err = NewRenderCreator().NewRender().RenderFragment("rowsTemplate", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<li class="row">1</li><li>2</li><li>3</li>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+	if html := render("rowsTemplate", props); !strings.Contains(html, `<li class="row" id="x">1</li>`) {
+		t.Fatalf("component root should inherit attrs: %s", html)
+	}
+}
+
+// 同名的变量按 模板中声明的变量 > props > 计算属性 > 全局变量 的顺序查找
+func TestResolveOrder(t *testing.T) {
+	newRender := func(order []ScopeLayer) *Render {
+		c := NewRenderCreator()
+		c.ResolveOrder = order
+		for _, k := range []string{"user", "site", "theme"} {
+			c.Var.Set(k, "global")
+		}
+		r := c.NewRender()
+		for _, k := range []string{"user", "site"} {
+			r.Computed(k, func(r *Render) interface{} {
+				return "computed"
+			})
+		}
+		return r
+	}
+	props := map[string]interface{}{"user": "props", "users": []interface{}{"loop"}}
+
+	res, err := newRender(nil).RenderFull("resolveOrder", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<div><p>props</p><p>computed</p><p>global</p><i>loop</i></div>`; res.Body != want {
+		t.Fatalf("html = %s; want: %s", res.Body, want)
+	}
+
+	// 可以修改查找顺序
+	res, err = newRender([]ScopeLayer{LayerGlobal, LayerComputed, LayerProps, LayerLoop}).RenderFull("resolveOrder", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<div><p>global</p><p>global</p><p>global</p><i>global</i></div>`; res.Body != want {
+		t.Fatalf("html = %s; want: %s", res.Body, want)
+	}
+
+	// Lookup返回变量所在的层
+	r := newRender(nil)
+	scope := extendScope(r.Global, props)
+	for k, want := range map[string]ScopeLayer{"user": LayerProps, "site": LayerComputed, "theme": LayerGlobal} {
+		if _, layer, ok := scope.Lookup(k); !ok || layer != want {
+			t.Fatalf("%s in layer %s; want: %s", k, layer, want)
+		}
+	}
+}
+
+// bool属性在html模式下只输出属性名, XHTML模式下输出为disabled="disabled"
+func TestXHTMLBoolAttr(t *testing.T) {
+	c := NewRenderCreator()
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("boolButton", w, &Options{Props: NewProps(map[string]interface{}{"disabled": true})})
+	if html, want := w.Result(), `<button class="b" disabled>x</button>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	c.XHTML = true
+	r = c.NewRender()
+	w = r.NewWriter()
+	r.Render("boolButton", w, &Options{Props: NewProps(map[string]interface{}{"disabled": true})})
+	if html, want := w.Result(), `<button class="b" disabled="disabled">x</button>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	if html := render("boolButton", map[string]interface{}{"disabled": false}); html != `<button class="b">x</button>` {
+		t.Fatalf("false bool attr should be omitted: %s", html)
+	}
+}
+
+// OnComponentRendered处理每个组件的输出, 子组件的输出先被处理
+func TestOnComponentRendered(t *testing.T) {
+	c := NewRenderCreator()
+	var names []string
+	c.OnComponentRendered = func(name string, html string) string {
+		names = append(names, name)
+		return "[" + name + "]" + html + "[/" + name + "]"
+	}
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("loopCardList", w, &Options{Props: NewProps(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "price": 1},
+			map[string]interface{}{"name": "b", "price": 2},
+		},
+	})})
+
+	want := `[loopCardList]<div>` +
+		`[loopCard]<div class="card">a:1</div>[/loopCard]` +
+		`[loopCard]<div class="card">b:2</div>[/loopCard]` +
+		`</div>[/loopCardList]`
+	if html := w.Result(); html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+	if got := strings.Join(names, ","); got != "loopCard,loopCard,loopCardList" {
+		t.Fatalf("hook called for %s", got)
+	}
+}
+
+// 开启ExtractStyles后组件根节点的style被提取到样式表中, 节点上使用生成的class
+func TestExtractStyles(t *testing.T) {
+	c := NewRenderCreator()
+	c.ExtractStyles = true
+	res, err := c.NewRender().RenderFull("styledCardParent", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := regexp.MustCompile(`^<div><div class="card active shadow wide (s-[0-9a-f]{8})">card</div></div>$`).FindStringSubmatch(res.Body)
+	if m == nil {
+		t.Fatalf("body = %s", res.Body)
+	}
+	if want := "." + m[1] + "{border: 0; color: blue; margin: 8px; padding: 1px;}"; res.Styles != want {
+		t.Fatalf("styles = %s; want: %s", res.Styles, want)
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	c := NewRenderCreator()
+	c.Instrument = true
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("leafList", w, &Options{Props: NewProps(map[string]interface{}{
+		"list": []interface{}{"a", "b", "c"},
+	})})
+	if html, want := w.Result(), `<ul><li>a</li><li>b</li><li>c</li></ul>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	stats := r.Stats()
+	if len(stats) != 2 || stats["leafList"].Count != 1 || stats["leafItem"].Count != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	// 父组件的耗时包括子组件
+	if stats["leafList"].Time < stats["leafItem"].Time {
+		t.Fatalf("leafList time %s < leafItem time %s", stats["leafList"].Time, stats["leafItem"].Time)
+	}
+
+	// 复用Render时重新统计
+	r.Render("leafList", r.NewWriter(), &Options{Props: NewProps(map[string]interface{}{
+		"list": []interface{}{"a"},
+	})})
+	stats = r.Stats()
+	if len(stats) != 2 || stats["leafList"].Count != 1 || stats["leafItem"].Count != 1 {
+		t.Fatalf("stats after reuse = %+v", stats)
+	}
+
+	// 默认不统计
+	r = NewRenderCreator().NewRender()
+	r.Render("leafList", r.NewWriter(), &Options{})
+	if stats := r.Stats(); stats != nil {
+		t.Fatalf("stats = %+v; want nil", stats)
+	}
+}
+
+// range(start, end)包含start, 不包含end
+func TestVForRange(t *testing.T) {
+	html := render("vForRange", nil)
+	want := `<div>` +
+		`<i>0:1</i><i>1:2</i><i>2:3</i><i>3:4</i>` +
+		`<b>0</b><b>1</b><b>2</b>` +
+		`<u>5</u><u>3</u><u>1</u>` +
+		`</div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// 值为null的属性不会输出, 而不是输出title=""或title="null"
+func TestConditionalAttr(t *testing.T) {
+	html := render("condAttr", map[string]interface{}{"show": true, "text": "hi"})
+	if want := `<div><a href="/" title="hi">link</a><span data-tip="hi">tip</span></div>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	html = render("condAttr", map[string]interface{}{"show": false, "text": "hi"})
+	if want := `<div><a href="/">link</a><span>tip</span></div>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// 插槽内容中引用组件没有传递的作用域prop时输出为空, 没有传递任何prop的插槽也一样
+func TestSlotScopeMissing(t *testing.T) {
+	html := render("slotScopeMissing", map[string]interface{}{"list": []interface{}{1}})
+	if want := `<div><ul><li>[1|||n]</li></ul><p>[|]</p></div>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// 组件(包括动态组件)上没有值的属性作为值为true的prop传递, 写明了空值的属性仍然是attr
+func TestValuelessProp(t *testing.T) {
+	html := render("toggleList", nil)
+	if want := `<div><span>open:true</span><span open>closed:</span><span>closed:</span>` +
+		`<span>open:true</span><span open>closed:</span></div>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// 插值中的bool值和vue一样输出为true/false
+func TestBoolText(t *testing.T) {
+	html := render("boolText", map[string]interface{}{"done": true, "hidden": false})
+	if want := `<p>done: true, hidden: false</p>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+// Render.Locale设置<html>的lang与dir, 没有设置时使用模板中的lang
+func TestLocaleAttrs(t *testing.T) {
+	props := map[string]interface{}{"title": "t", "list": []interface{}{}}
+	for locale, want := range map[string]string{
+		"":      `<html lang="en">`,
+		"ar-EG": `<html lang="ar-EG" dir="rtl">`,
+		"he":    `<html lang="he" dir="rtl">`,
+		"zh_CN": `<html lang="zh-CN" dir="ltr">`,
+	} {
+		r := NewRenderCreator().NewRender()
+		r.Locale = locale
+		res, err := r.RenderFull("docPage", props)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(res.Body, want) {
+			t.Fatalf("locale %q: html = %s; want: %s", locale, res.Body, want)
+		}
+	}
+
+	// lang/dir在原来的位置输出, 模板中的值会被转义
+	for locale, want := range map[string]string{
+		"":   `<html lang="a&#34;b" data-theme="light" dir="ltr">`,
+		"he": `<html lang="he" data-theme="light" dir="rtl">`,
+	} {
+		r := NewRenderCreator().NewRender()
+		r.Locale = locale
+		res, err := r.RenderFull("localePage", props)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(res.Body, want) {
+			t.Fatalf("locale %q: html = %s; want: %s", locale, res.Body, want)
+		}
+	}
+}
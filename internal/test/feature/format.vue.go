@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:8b147ec99a552e2bbdda22a40a5d91c9
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_format(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("format", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("format", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>")
+			w.WriteString(interfaceToStr(execFilter(r, "date", scope.Get("createdAt"), []interface{}{"2006-01-02"}...), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(execFilter(r, "date", scope.Get("createdAt")), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(execFilter(r, "number", scope.Get("price"), []interface{}{2}...), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(execFilter(r, "number", scope.Get("price"), []interface{}{2, "de"}...), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(execFilter(r, "number", scope.Get("count")), true))
+			w.WriteString("</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,55 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:758cb76ed2af3cb1a8b2765942d38ec2
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_fullPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("fullPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("fullPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_layout(r, w, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_myBtn(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("ok")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+			_teleport(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "to", Val: "#modal"},
+				},
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("<p>modal</p>")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}, "header": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<title>")
+
+			collectTitle(r, w, func(w Writer) {
+				w.WriteString(interfaceToStr(scope.Get("name"), true) + " - Home")
+			})
+
+			w.WriteString("</title>")
+			releaseScope(r, scope)
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
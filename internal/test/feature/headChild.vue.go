@@ -0,0 +1,46 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:77fc19b6417a5cf780e7d11cc4a4ef8c
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_headChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("headChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("headChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "section", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			collectHead(r, "title", func(w Writer) {
+				w.WriteString("<title>")
+
+				collectTitle(r, w, func(w Writer) {
+					w.WriteString(interfaceToStr(scope.Get("title"), true) + " - Site")
+				})
+
+				w.WriteString("</title>")
+			})
+
+			collectHead(r, "meta:name=description", func(w Writer) {
+				w.WriteString("<meta" + mixinAttr(nil, []Attribute{
+					{Key: "name", Val: "description"},
+				}, Props{orderKey: []string{"content"}, data: map[string]interface{}{"content": interfaceAdd("About ", scope.Get("title"))}}) + "/>")
+			})
+
+			w.WriteString("<p>child</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,52 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:d5c3b74457a0a4035707304c07bb2c93
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_headPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("headPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("headPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			collectHead(r, "title", func(w Writer) {
+				w.WriteString("<title>")
+
+				collectTitle(r, w, func(w Writer) {
+					w.WriteString("Site")
+				})
+
+				w.WriteString("</title>")
+			})
+
+			collectHead(r, "meta:name=description", func(w Writer) {
+				w.WriteString("<meta name=\"description\" content=\"site\"/>")
+			})
+
+			collectHead(r, "meta:property=og:type", func(w Writer) {
+				w.WriteString("<meta property=\"og:type\" content=\"website\"/>")
+			})
+
+			xx_headChild(r, w, &Options{
+				Props: Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": scope.Get("title")}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0793a08a708be24d9f8bd9dc1fbeb57c
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_ifGroup(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("ifGroup", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("ifGroup", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			if interfaceToBool(scope.Get("show")) {
+				w.WriteString("<a href=\"#a\">a</a><b>b</b>")
+			}
+			w.WriteString("<span>mid</span>")
+
+			if interfaceToBool(scope.Get("show")) {
+				w.WriteString("<em>x</em><u>y</u>")
+			} else {
+				w.WriteString("<i>none</i>")
+			}
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
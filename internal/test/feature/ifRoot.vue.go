@@ -0,0 +1,35 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:936c54b94dd3c38d677592e4562ef150
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_ifRoot(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("ifRoot", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("ifRoot", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_ifRootChild(r, w, &Options{
+				Props: Props{orderKey: []string{"ok"}, data: map[string]interface{}{"ok": scope.Get("ok")}},
+				Class: []string{"parent"},
+				Style: map[string]string{"color": "red"},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,43 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:a4e1df5a2458f9f03565e3ee331590c8
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_ifRootChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("ifRootChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("ifRootChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+
+	if interfaceToBool(scope.Get("ok")) {
+		_tag(r, w, "p", true, &Options{
+			Class: []string{"yes"},
+			Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+				w.WriteString("yes")
+			}},
+			P:          options,
+			Directives: options.Directives,
+			Scope:      scope,
+		})
+	} else {
+		_tag(r, w, "span", true, &Options{
+			Class: []string{"no"},
+			Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+				w.WriteString("no")
+			}},
+			P:          options,
+			Directives: options.Directives,
+			Scope:      scope,
+		})
+	}
+	hookDone()
+	return
+}
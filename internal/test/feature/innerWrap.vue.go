@@ -0,0 +1,33 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:518726daadab09db562f42113f207349
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_innerWrap(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("innerWrap", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("innerWrap", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"inner"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
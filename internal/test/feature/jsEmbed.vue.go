@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0f0c57bc01f06269f38292b6b9e59317
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_jsEmbed(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("jsEmbed", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("jsEmbed", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<script" + nonceAttr(r) + ">var s = \"" + interfaceToStr(execFilter(r, "js", scope.Get("s")), true) + "\";</script>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,43 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:140c347e458eae7673d184285422429f
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_layout(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("layout", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("layout", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"layout"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<header>")
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "header"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</header><main>")
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</main>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
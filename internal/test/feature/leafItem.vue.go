@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:5b8b8c4de060b997c10d317eb96eaadc
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_leafItem(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("leafItem", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("leafItem", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "li", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("name"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
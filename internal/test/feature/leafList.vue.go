@@ -0,0 +1,41 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:d31e0df9af4c738ee8b4c11cda0289fa
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_leafList(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("leafList", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("leafList", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				xx_leafItem(r, w, &Options{
+					Props: Props{orderKey: []string{"name"}, data: map[string]interface{}{"name": scope.Get("item")}},
+					P:     options,
+					Scope: scope,
+				})
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,47 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:95fdfc29ccecadc574252b48e688c935
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_leafListSlot(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("leafListSlot", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("leafListSlot", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				xx_leafItem(r, w, &Options{
+					Props: Props{orderKey: []string{"name"}, data: map[string]interface{}{"name": scope.Get("item")}},
+					Slots: map[string]NamedSlotFunc{"extra": func(w Writer, props Props) {
+						scope := acquireScope(r, scope)
+						scope.Set("slotProps", props.Map())
+
+						releaseScope(r, scope)
+					}},
+					P:     options,
+					Scope: scope,
+				})
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,24 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:3bc399fc3a4e0874a7e521193fd29cc3
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_localePage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("localePage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("localePage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	w.WriteString("<!doctype html><html" + localeAttr(r, "lang", "a&#34;b") + " data-theme=\"light\"" + localeAttr(r, "dir", "ltr") + "><head></head><body><p>")
+	w.WriteString(interfaceToStr(scope.Get("title"), true))
+	w.WriteString("</p></body></html>")
+	hookDone()
+	return
+}
@@ -0,0 +1,30 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ac5217774e8b6e609032c02b5b346a0b
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_loopCard(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("loopCard", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("loopCard", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"card"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("item", "name"), true) + ":" + interfaceToStr(scope.Get("item", "price"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
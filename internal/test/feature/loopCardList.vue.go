@@ -0,0 +1,41 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:a8572540316dc41048a0d5b299af4f34
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_loopCardList(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("loopCardList", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("loopCardList", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("items"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				xx_loopCard(r, w, &Options{
+					Props: Props{orderKey: []string{"item"}, data: map[string]interface{}{"item": scope.Get("item")}},
+					P:     options,
+					Scope: scope,
+				})
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
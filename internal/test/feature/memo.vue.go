@@ -0,0 +1,43 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:3b7af6730ee0f06fd7304ff12a85710c
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_memo(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("memo", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("memo", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+
+				memo(r, "memo.vue:0", []interface{}{scope.Get("item", "id"), scope.Get("item", "done")}, w, func(w Writer) {
+					w.WriteString("<li>")
+					w.WriteString(interfaceToStr(interfaceToFunc(scope.Get("heavy"))(r, options, scope.Get("item", "name")), true))
+					w.WriteString("</li>")
+				})
+
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
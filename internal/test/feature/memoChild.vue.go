@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:21959da7656b318b20a11ac0ca69e16a
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_memoChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("memoChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("memoChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "b", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("child")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,56 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:35701de611077ce02f118c922b4d67fb
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_memoEffects(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("memoEffects", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("memoEffects", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			memo(r, "memoEffects.vue:0", []interface{}{scope.Get("page")}, w, func(w Writer) {
+				w.WriteString("<section><title>")
+
+				collectTitle(r, w, func(w Writer) {
+					w.WriteString("Page " + interfaceToStr(scope.Get("page"), true))
+				})
+
+				w.WriteString("</title><script" + nonceAttr(r) + ">var page = " + interfaceToStr(scope.Get("page"), true) + ";</script>")
+				_teleport(r, w, &Options{
+					Attrs: []Attribute{
+						{Key: "to", Val: "#modal"},
+					},
+					Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+						w.WriteString("<p>")
+						w.WriteString(interfaceToStr(scope.Get("page"), true))
+						w.WriteString("</p>")
+					}},
+					P:     options,
+					Scope: scope,
+				})
+				xx_memoChild(r, w, &Options{
+					P:     options,
+					Scope: scope,
+				})
+				w.WriteString("</section>")
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,37 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:88f5d8953c181e8ea877bbeccc2e33f2
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_memoStyle(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("memoStyle", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("memoStyle", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			memo(r, "memoStyle.vue:0", []interface{}{scope.Get("color")}, w, func(w Writer) {
+				xx_memoStyleChild(r, w, &Options{
+					PropsStyle: map[string]interface{}{"color": scope.Get("color")},
+					P:          options,
+					Scope:      scope,
+				})
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
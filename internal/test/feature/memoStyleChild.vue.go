@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:93796f8e980b25f4aa09ed1189ff83dc
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_memoStyleChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("memoStyleChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("memoStyleChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("styled")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
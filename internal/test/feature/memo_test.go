@@ -0,0 +1,54 @@
+package feature
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// 使用v-memo的缓存时, 渲染的副作用(标题, teleport, 渲染过的组件)和不使用缓存时相同, nonce不会使用缓存中的值
+func TestVMemoEffects(t *testing.T) {
+	c := NewRenderCreator()
+	for _, nonce := range []string{"a", "a", "b"} {
+		r := c.NewRender()
+		r.Nonce = nonce
+		res, err := r.RenderFull("memoEffects", map[string]interface{}{"page": 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := `<div><section><title>Page 1</title><script nonce="` + nonce + `">var page = 1;</script><b>child</b></section></div>`
+		if res.Body != want {
+			t.Fatalf("nonce %s: html = %s; want: %s", nonce, res.Body, want)
+		}
+		if res.Title != "Page 1" {
+			t.Fatalf("nonce %s: title = %s", nonce, res.Title)
+		}
+		if res.Teleports["#modal"] != "<p>1</p>" {
+			t.Fatalf("nonce %s: teleports = %v", nonce, res.Teleports)
+		}
+		if got := strings.Join(res.Components, ","); got != "memoChild,memoEffects" {
+			t.Fatalf("nonce %s: components = %s", nonce, got)
+		}
+	}
+}
+
+// 开启ExtractStyles时, 使用v-memo的缓存也会把样式加入本次渲染的样式表
+func TestVMemoExtractStyles(t *testing.T) {
+	c := NewRenderCreator()
+	c.ExtractStyles = true
+	for i := 0; i < 2; i++ {
+		res, err := c.NewRender().RenderFull("memoStyle", map[string]interface{}{"color": "red"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := regexp.MustCompile(`^<div><p class="(s-[0-9a-f]{8})">styled</p></div>$`).FindStringSubmatch(res.Body)
+		if m == nil {
+			t.Fatalf("render %d: body = %s", i, res.Body)
+		}
+		if want := "." + m[1] + "{color: red;}"; res.Styles != want {
+			t.Fatalf("render %d: styles = %s; want: %s", i, res.Styles, want)
+		}
+	}
+}
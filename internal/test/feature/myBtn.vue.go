@@ -0,0 +1,33 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ab90afc21e2b07b695d339cb358641d8
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_myBtn(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("myBtn", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("myBtn", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "button", true, &Options{
+		Class: []string{"btn"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
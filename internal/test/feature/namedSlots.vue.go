@@ -0,0 +1,43 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:5ff2dd41ce931ce105c0e78a1bd7e278
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_namedSlots(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("namedSlots", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("namedSlots", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_namedSlotsChild(r, w, &Options{
+		Slots: map[string]NamedSlotFunc{"body": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("B")
+			releaseScope(r, scope)
+		}, "default": func(w Writer, props Props) {
+			w.WriteString("<p>default</p>")
+		}, "footer": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("F")
+			releaseScope(r, scope)
+		}, "header": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("H")
+			releaseScope(r, scope)
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,57 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:48d2377cf0d7223899dd9444e97448ed
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_namedSlotsChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("namedSlotsChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("namedSlotsChild", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<header>")
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "header"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</header><main>")
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "body"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</main><footer>")
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "footer"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</footer>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
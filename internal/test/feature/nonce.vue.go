@@ -0,0 +1,34 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:c2cb045d6e3275f1319378c63c0e0668
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_nonce(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("nonce", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("nonce", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<style" + nonceAttr(r) + ">p > a { color: red }</style><script" + nonceAttr(r) + ">if (a < b && c) { run() }</script>")
+
+			if interfaceToBool(scope.Get("show")) {
+				w.WriteString("<script src=\"/app.js\"" + nonceAttr(r) + "></script>")
+			}
+			w.WriteString("<p>a &amp; b</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,31 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:1b186f1197bda5df51a47a2e6c8376f9
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_numAttr(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("numAttr", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("numAttr", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<input type=\"number\" tabindex=\"-1\" step=\"0.5\" min=\"-10.25\" max=\"1e3\"/><input" + mixinAttr(nil, []Attribute{
+				{Key: "type", Val: "number"},
+			}, Props{orderKey: []string{"tabindex", "step", "min", "max"}, data: map[string]interface{}{"tabindex": -1, "step": 0.5, "min": scope.Get("min"), "max": 1000000}}) + "/>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
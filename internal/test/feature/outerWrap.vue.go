@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:55fe7b61995fb1394202fb6b0a77f9a1
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_outerWrap(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("outerWrap", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("outerWrap", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "section", true, &Options{
+		Class: []string{"outer"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_innerWrap(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					_slot(r, w, &Options{
+						P:     options,
+						Scope: scope,
+					})
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,43 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:1c6c95b47d2a84ebede9843eaddfcaf8
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_partial(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("partial", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("partial", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<div id=\"header\">")
+			w.WriteString(interfaceToStr(scope.Get("title"), true))
+			w.WriteString("</div><div id=\"main\">")
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("<p>")
+				w.WriteString(interfaceToStr(scope.Get("item"), true))
+				w.WriteString("</p>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("</div>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
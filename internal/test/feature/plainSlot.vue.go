@@ -0,0 +1,32 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:e217866f660b58f0253b4280f27a1a8a
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_plainSlot(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("plainSlot", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("plainSlot", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:7c96a768b6d06a522ec1a9227ee783af
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_plural(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("plural", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("plural", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("count"), true) + " " + interfaceToStr(interfaceToFunc(scope.Get("plural"))(r, options, scope.Get("count"), "item", "items"), true) + ", " + interfaceToStr(execFilter(r, "plural", scope.Get("count"), []interface{}{"box", "boxes"}...), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,33 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:4f02b3d1e3d4a7f054b24c9a6d3933bb
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_raw(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("raw", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("raw", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>")
+			w.WriteString(interfaceToStr(execFilter(r, "raw", scope.Get("trustedHtml")), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(scope.Get("trustedHtml"), true))
+			w.WriteString("</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
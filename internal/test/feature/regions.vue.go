@@ -0,0 +1,54 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ccfa276a2bef725d6d14a5f3d7390538
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_regions(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("regions", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("regions", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"page"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<header>")
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "header"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</header><main>")
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "body"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</main><footer>")
+			_slot(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "name", Val: "footer"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</footer>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,55 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:e26bc130340ee4e4bb64dc86ffeaccac
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_regionsMerge(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("regionsMerge", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("regionsMerge", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_regions(r, w, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_layout(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"header": func(w Writer, props Props) {
+					scope := acquireScope(r, scope)
+					scope.Set("slotProps", props.Map())
+					w.WriteString("Card")
+					releaseScope(r, scope)
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}, "footer": func(w Writer, props Props) {
+			(func(w Writer, props Props) {
+				scope := acquireScope(r, scope)
+				scope.Set("slotProps", props.Map())
+				w.WriteString("<script" + nonceAttr(r) + ">var a = 1</script>")
+				releaseScope(r, scope)
+			})(w, props)
+			(func(w Writer, props Props) {
+				scope := acquireScope(r, scope)
+				scope.Set("slotProps", props.Map())
+				w.WriteString("<script" + nonceAttr(r) + ">var b = 2</script>")
+				releaseScope(r, scope)
+			})(w, props)
+		}, "header": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<h1>Title</h1>")
+			releaseScope(r, scope)
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,41 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:f8f332a0941fef8ae286320b5844cede
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_regionsParent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("regionsParent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("regionsParent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_regions(r, w, &Options{
+		Slots: map[string]NamedSlotFunc{"body": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<article>Body</article>")
+			releaseScope(r, scope)
+		}, "footer": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<p>Footer</p>")
+			releaseScope(r, scope)
+		}, "header": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<h1>Title</h1>")
+			releaseScope(r, scope)
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
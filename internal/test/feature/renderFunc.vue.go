@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:12ca8c97baa0dc4e5edfc7ecb5b16f11
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_renderFunc(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("renderFunc", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("renderFunc", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "p", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(interfaceToFunc(scope.Get("sum"))(r, options, scope.Get("x"), 2), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
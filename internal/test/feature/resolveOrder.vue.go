@@ -0,0 +1,46 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:420b4a6618b7737d587c9c928e942de5
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_resolveOrder(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("resolveOrder", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("resolveOrder", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>")
+			w.WriteString(interfaceToStr(scope.Get("user"), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(scope.Get("site"), true))
+			w.WriteString("</p><p>")
+			w.WriteString(interfaceToStr(scope.Get("theme"), true))
+			w.WriteString("</p>")
+
+			forRange(r, scope.Get("users"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("user", item)
+				w.WriteString("<i>")
+				w.WriteString(interfaceToStr(scope.Get("user"), true))
+				w.WriteString("</i>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,28 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:ba3005cc209ced341653bbad223e86a9
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_rows(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("rows", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("rows", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	w.WriteString("<tr class=\"row\"><td>")
+	w.WriteString(interfaceToStr(scope.Get("a"), true))
+	w.WriteString("</td></tr><tr><td>")
+	w.WriteString(interfaceToStr(scope.Get("b"), true))
+	w.WriteString("</td></tr><tr><td>")
+	w.WriteString(interfaceToStr(scope.Get("c"), true))
+	w.WriteString("</td></tr>")
+	hookDone()
+	return
+}
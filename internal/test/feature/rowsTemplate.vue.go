@@ -0,0 +1,46 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:fb8ba897f6749df87fa0e65ff819c4a7
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_rowsTemplate(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("rowsTemplate", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("rowsTemplate", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "li", true, &Options{
+		Class: []string{"row"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("a"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	_tag(r, w, "li", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("b"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	_tag(r, w, "li", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("c"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
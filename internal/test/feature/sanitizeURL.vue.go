@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:101c58663dd6fca6ba3ed1ec07ff5b37
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_sanitizeURL(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("sanitizeURL", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("sanitizeURL", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<a" + mixinAttr(nil, nil, Props{orderKey: []string{"href"}, data: map[string]interface{}{"href": interfaceToFunc(scope.Get("$sanitizeURL"))(r, options, scope.Get("bad"))}}) + ">bad</a><a" + mixinAttr(nil, nil, Props{orderKey: []string{"href"}, data: map[string]interface{}{"href": scope.Get("bad")}}) + ">raw</a>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
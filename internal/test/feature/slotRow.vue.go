@@ -0,0 +1,49 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0e6feda4c414b5af81644fba712ce033
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_slotRow(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("slotRow", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("slotRow", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("data", item)
+				w.WriteString("<li>")
+				_slot(r, w, &Options{
+					Props: Props{orderKey: []string{"item"}, data: map[string]interface{}{"item": scope.Get("data")}},
+					Attrs: []Attribute{
+						{Key: "name", Val: "row"},
+					},
+					Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+						w.WriteString("fallback " + interfaceToStr(scope.Get("data"), true))
+					}},
+					P:     options,
+					Scope: scope,
+				})
+				w.WriteString("</li>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,44 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:3ac15a31f5dbf52d3731845a0eb8b07d
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_slotRowParent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("slotRowParent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("slotRowParent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_slotRow(r, w, &Options{
+				Props: Props{orderKey: []string{"list"}, data: map[string]interface{}{"list": scope.Get("list")}},
+				Slots: map[string]NamedSlotFunc{"row": func(w Writer, props Props) {
+					scope := acquireScope(r, scope)
+					scope.Set("p", props.Map())
+					w.WriteString("row " + interfaceToStr(scope.Get("p", "item"), true))
+					releaseScope(r, scope)
+				}},
+				P:     options,
+				Scope: scope,
+			})
+			xx_slotRow(r, w, &Options{
+				Props: Props{orderKey: []string{"list"}, data: map[string]interface{}{"list": scope.Get("list")}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
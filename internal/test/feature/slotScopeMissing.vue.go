@@ -0,0 +1,60 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:6b6131fe9a3ab1c70abc563df4c289b0
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_slotScopeMissing(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("slotScopeMissing", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("slotScopeMissing", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_slotRow(r, w, &Options{
+				Props: Props{orderKey: []string{"list"}, data: map[string]interface{}{"list": scope.Get("list")}},
+				Slots: map[string]NamedSlotFunc{"row": func(w Writer, props Props) {
+					scope := acquireScope(r, scope)
+					scope.Set("p", props.Map())
+					w.WriteString("[" + interfaceToStr(scope.Get("p", "item"), true) + "|" + interfaceToStr(scope.Get("p", "extra"), true) + "|" + interfaceToStr(scope.Get("p", "extra", "name"), true) + "|" + interfaceToStr(func() interface{} {
+						if interfaceToBool(scope.Get("p", "extra")) {
+							return "y"
+						}
+						return "n"
+					}(), true) + "]")
+					releaseScope(r, scope)
+				}},
+				P:     options,
+				Scope: scope,
+			})
+			xx_plainSlot(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					_template(r, w, &Options{
+						Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+							w.WriteString("[" + interfaceToStr(scope.Get("p", "item"), true) + "|" + interfaceToStr(scope.Get("p", "item", "name"), true) + "]")
+						}},
+						P: options,
+						Directives: []directive{
+							{Name: "v-slot", Value: scope.Get("p"), Arg: ""},
+						},
+						Scope: scope,
+					})
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
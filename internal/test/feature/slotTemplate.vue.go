@@ -0,0 +1,33 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:bdfb738349c33f4fedf38650591a488b
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_slotTemplate(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("slotTemplate", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("slotTemplate", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	xx_layout(r, w, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>body</p>")
+		}, "header": func(w Writer, props Props) {
+			scope := acquireScope(r, scope)
+			scope.Set("slotProps", props.Map())
+			w.WriteString("<h1>Title</h1>")
+			releaseScope(r, scope)
+		}},
+		P:     options,
+		Scope: scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,32 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:6dccde69f82e917aee79fa7adf34e45f
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_styledCard(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("styledCard", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("styledCard", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		PropsClass: map[string]interface{}{"active": scope.Get("active")},
+		Class:      []string{"card"},
+		Style:      map[string]string{"color": "red", "padding": "1px"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("card")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
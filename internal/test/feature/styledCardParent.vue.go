@@ -0,0 +1,37 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:43e2deac5a042e7b040a60f7452306da
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_styledCardParent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("styledCardParent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("styledCardParent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_styledCard(r, w, &Options{
+				PropsClass: []interface{}{"wide"},
+				PropsStyle: map[string]interface{}{"color": "blue", "margin": "8px"},
+				Props:      Props{orderKey: []string{"active"}, data: map[string]interface{}{"active": true}},
+				Class:      []string{"shadow"},
+				Style:      map[string]string{"border": "0", "color": "green"},
+				P:          options,
+				Scope:      scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
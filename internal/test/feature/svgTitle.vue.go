@@ -0,0 +1,35 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:1b02fc7f7b2e19a07f6cc19a3028d5e2
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_svgTitle(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("svgTitle", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("svgTitle", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<title>")
+
+			collectTitle(r, w, func(w Writer) {
+				w.WriteString(interfaceToStr(scope.Get("name"), true))
+			})
+
+			w.WriteString("</title><svg" + mixinClass(nil, nil, scope.Get("cls")) + "><title>Close</title><path d=\"M0 0\"></path></svg>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
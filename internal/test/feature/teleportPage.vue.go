@@ -0,0 +1,50 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:8bb43ba1c0faad9992b58750bc864d2a
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_teleportPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("teleportPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("teleportPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"page"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>main</p>")
+			_teleport(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "to", Val: "#modal"},
+				},
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("<div class=\"modal\">")
+					w.WriteString(interfaceToStr(scope.Get("msg"), true))
+					w.WriteString("</div>")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+			_teleport(r, w, &Options{
+				Props: Props{orderKey: []string{"to"}, data: map[string]interface{}{"to": scope.Get("target")}},
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("<span>2</span>")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,47 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:f9ff2b0706b2a2b6036df1811f1820f3
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_textareaForm(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("textareaForm", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("textareaForm", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "form", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<textarea name=\"a\">")
+
+			keepLeadingNewline(r, w, func(w Writer) {
+				w.WriteString(interfaceToStr(scope.Get("value"), true))
+			})
+
+			w.WriteString("</textarea><textarea name=\"b\">")
+
+			keepLeadingNewline(r, w, func(w Writer) {
+				w.WriteString("first &amp; " + interfaceToStr(scope.Get("value"), true))
+			})
+
+			w.WriteString("</textarea><pre>")
+
+			keepLeadingNewline(r, w, func(w Writer) {
+				w.WriteString(interfaceToStr(scope.Get("value"), true))
+			})
+
+			w.WriteString("</pre>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,29 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:22fb91b313e3cc7da7ba9340667ee4b7
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_titled(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("titled", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("titled", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "h1", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("title"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
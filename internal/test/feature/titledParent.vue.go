@@ -0,0 +1,44 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:703f68c009b1a97a6102858d1ad77236
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_titledParent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("titledParent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("titledParent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_titled(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+			xx_titled(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "title", Val: "Hello"},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			xx_titled(r, w, &Options{
+				Props: Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": scope.Get("name")}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
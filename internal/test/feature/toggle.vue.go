@@ -0,0 +1,34 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:63c0cce8901e97d9af7158ced2c3749f
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_toggle(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("toggle", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("toggle", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "span", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(func() interface{} {
+				if interfaceToBool(scope.Get("open")) {
+					return "open"
+				}
+				return "closed"
+			}(), true) + ":" + interfaceToStr(scope.Get("open"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,57 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:7aa1d68da4d517f86437d89ed149aa2c
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_toggleList(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("toggleList", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("toggleList", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_toggle(r, w, &Options{
+				Props: Props{orderKey: []string{"open"}, data: map[string]interface{}{"open": true}},
+				P:     options,
+				Scope: scope,
+			})
+			xx_toggle(r, w, &Options{
+				Attrs: []Attribute{
+					{Key: "open", Val: ""},
+				},
+				P:     options,
+				Scope: scope,
+			})
+			xx_toggle(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+			_component(r, w, &Options{
+				Props: Props{orderKey: []string{"is", "open"}, data: map[string]interface{}{"is": "toggle", "open": true}},
+				P:     options,
+				Scope: scope,
+			})
+			_component(r, w, &Options{
+				Props: Props{orderKey: []string{"is"}, data: map[string]interface{}{"is": "toggle"}},
+				Attrs: []Attribute{
+					{Key: "open", Val: ""},
+				},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
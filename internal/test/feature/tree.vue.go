@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:a53e5b8074d3e073bb7b9af985923624
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_tree(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("tree", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("tree", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<li>")
+			w.WriteString(interfaceToStr(scope.Get("node", "name"), true))
+
+			if interfaceToBool(scope.Get("node", "child")) {
+				xx_tree(r, w, &Options{
+					Props: Props{orderKey: []string{"node"}, data: map[string]interface{}{"node": scope.Get("node", "child")}},
+					P:     options,
+					Scope: scope,
+				})
+			}
+			w.WriteString("</li>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,59 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:bd45478c42651a5b82425bc9ad449295
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForExp(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForExp", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForExp", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, interfaceToFunc(scope.Get("items", "filter"))(r, options, scope.Get("isActive")), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("<p>")
+				w.WriteString(interfaceToStr(scope.Get("item", "name"), true))
+				w.WriteString("</p>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, interfaceToFunc(scope.Get("getItems"))(r, options, 2), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("<i>")
+				w.WriteString(interfaceToStr(scope.Get("item"), true))
+				w.WriteString("</i>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, scope.Get("users"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("<b>")
+				w.WriteString(interfaceToStr(scope.Get("item"), true))
+				w.WriteString("</b>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:c152a92d16f835feb2483c7e307781c6
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForLimit(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForLimit", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForLimit", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("<li>")
+				w.WriteString(interfaceToStr(scope.Get("item"), true))
+				w.WriteString("</li>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
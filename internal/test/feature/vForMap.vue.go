@@ -0,0 +1,49 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:90bd13bf77b4005a56cd25310c5aaff2
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForMap(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForMap", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForMap", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("names"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("k", index)
+				scope.Set("v", item)
+				w.WriteString("<p>")
+				w.WriteString(interfaceToStr(scope.Get("k"), true) + "=" + interfaceToStr(scope.Get("v"), true))
+				w.WriteString("</p>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, scope.Get("codes"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("k", index)
+				scope.Set("v", item)
+				w.WriteString("<p>")
+				w.WriteString(interfaceToStr(scope.Get("k"), true) + "=" + interfaceToStr(scope.Get("v"), true))
+				w.WriteString("</p>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,59 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:a76c3ca300f11c3fe688ef834953ef21
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForNested(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForNested", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForNested", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("groups"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("group", item)
+				w.WriteString("<section>")
+
+				forRange(r, scope.Get("group", "rows"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("r", index)
+					scope.Set("row", item)
+					w.WriteString("<ul>")
+
+					forRange(r, scope.Get("row", "cols"), func(index interface{}, item interface{}) {
+						scope := acquireScope(r, scope)
+						scope.Set("c", index)
+						scope.Set("col", item)
+						w.WriteString("<li>")
+						w.WriteString(interfaceToStr(scope.Get("r"), true) + "-" + interfaceToStr(scope.Get("c"), true) + "-" + interfaceToStr(scope.Get("row", "name"), true) + "-" + interfaceToStr(scope.Get("col"), true) + "-" + interfaceToStr(scope.Get("$index"), true))
+						w.WriteString("</li>")
+						releaseScope(r, scope)
+					})
+
+					w.WriteString("</ul>")
+					releaseScope(r, scope)
+				})
+
+				w.WriteString("</section>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
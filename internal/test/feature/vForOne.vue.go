@@ -0,0 +1,73 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:cad36a3200a81a547f552d5c599f183e
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForOne(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForOne", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForOne", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>")
+
+			forRangeOne(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("i", index)
+				scope.Set("item", item)
+				w.WriteString("<span>")
+				w.WriteString(interfaceToStr(scope.Get("i"), true) + ":" + interfaceToStr(scope.Get("item"), true) + ";</span>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("</p><p>")
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("i", index)
+				scope.Set("item", item)
+				w.WriteString("<span>")
+				w.WriteString(interfaceToStr(scope.Get("i"), true) + ":" + interfaceToStr(scope.Get("item"), true) + ";</span>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("</p><p>")
+
+			forRangeOne(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("<span>")
+				w.WriteString(interfaceToStr(scope.Get("$index"), true) + ";</span>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("</p><p>")
+
+			forRangeOne(r, scope.Get("obj"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("k", index)
+				scope.Set("v", item)
+				w.WriteString("<span>")
+				w.WriteString(interfaceToStr(scope.Get("k"), true) + "=" + interfaceToStr(scope.Get("v"), true) + ";</span>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,39 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:355f981abc1769d853d6012249788393
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForPath(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForPath", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForPath", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "ul", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("obj", "nested", "list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("key", index)
+				scope.Set("val", item)
+				w.WriteString("<li>")
+				w.WriteString(interfaceToStr(scope.Get("key"), true) + ":" + interfaceToStr(scope.Get("val", "name"), true))
+				w.WriteString("</li>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,46 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:463ce69b38b784bdbc8b216cbb04cbd7
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForPool(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForPool", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForPool", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				_tag(r, w, "p", false, &Options{
+					Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+						w.WriteString(interfaceToStr(scope.Get("item", "name"), true) + interfaceToStr(scope.Get("marked"), true))
+					}},
+					P: options,
+					Directives: []directive{
+						{Name: "v-mark", Value: scope.Get("item", "mark"), Arg: ""},
+					},
+					Scope: scope,
+				})
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
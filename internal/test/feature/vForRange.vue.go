@@ -0,0 +1,69 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:2eee2d3e716ac7dd74e47e0b4e530d69
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForRange(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForRange", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForRange", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, interfaceToFunc(scope.Get("range"))(r, options, 1, 5), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("i", index)
+				scope.Set("n", item)
+				w.WriteString("<i>")
+				w.WriteString(interfaceToStr(scope.Get("i"), true) + ":" + interfaceToStr(scope.Get("n"), true))
+				w.WriteString("</i>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, interfaceToFunc(scope.Get("range"))(r, options, 3), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("n", item)
+				w.WriteString("<b>")
+				w.WriteString(interfaceToStr(scope.Get("n"), true))
+				w.WriteString("</b>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, interfaceToFunc(scope.Get("range"))(r, options, 5, 0, -2), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("n", item)
+				w.WriteString("<u>")
+				w.WriteString(interfaceToStr(scope.Get("n"), true))
+				w.WriteString("</u>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, interfaceToFunc(scope.Get("range"))(r, options, 2, 2), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("n", item)
+				w.WriteString("<s>")
+				w.WriteString(interfaceToStr(scope.Get("n"), true))
+				w.WriteString("</s>")
+				releaseScope(r, scope)
+			})
+
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
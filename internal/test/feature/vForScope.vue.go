@@ -0,0 +1,52 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0c52c490e05c3f01ac8e447c809d1494
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vForScope(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vForScope", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vForScope", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, scope.Get("as"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("a", item)
+				w.WriteString("<p>")
+				w.WriteString(interfaceToStr(scope.Get("$index"), true) + "-" + interfaceToStr(scope.Get("a"), true))
+				w.WriteString("</p>")
+				releaseScope(r, scope)
+			})
+
+			forRange(r, scope.Get("bs"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("i", index)
+				scope.Set("b", item)
+				w.WriteString("<span>")
+				w.WriteString(interfaceToStr(scope.Get("i"), true) + "-" + interfaceToStr(scope.Get("b"), true) + ":" + interfaceToStr(scope.Get("$index"), true))
+				w.WriteString("</span>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("<i>")
+			w.WriteString(interfaceToStr(scope.Get("$index"), true) + interfaceToStr(scope.Get("a"), true) + interfaceToStr(scope.Get("i"), true))
+			w.WriteString("</i>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
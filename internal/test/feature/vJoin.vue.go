@@ -0,0 +1,130 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:73b84cf9d4ed51dfe329bcc6c42cd2ae
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vJoin(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vJoin", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vJoin", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<p>")
+			{
+				vJoined := false
+
+				forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("item", item)
+
+					if vJoined {
+						w.WriteString(", ")
+					}
+					vJoined = true
+					w.WriteString("<span>")
+					w.WriteString(interfaceToStr(scope.Get("item"), true))
+					w.WriteString("</span>")
+
+					releaseScope(r, scope)
+				})
+
+			}
+			w.WriteString("</p><p>")
+			{
+				vJoined := false
+
+				forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("item", item)
+
+					if vJoined {
+						w.WriteString(" &amp; ")
+					}
+					vJoined = true
+					w.WriteString(interfaceToStr(scope.Get("item"), true))
+
+					releaseScope(r, scope)
+				})
+
+			}
+			w.WriteString("</p><p>")
+			{
+				vJoined := false
+
+				forRange(r, scope.Get("list"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("item", item)
+
+					if interfaceToBool(interfaceToStr(scope.Get("item")) != interfaceToStr("b")) {
+						if vJoined {
+							w.WriteString("|")
+						}
+						vJoined = true
+						w.WriteString("<b>")
+						w.WriteString(interfaceToStr(scope.Get("item"), true))
+						w.WriteString("</b>")
+
+					}
+					releaseScope(r, scope)
+				})
+
+			}
+			w.WriteString("</p><p>")
+			{
+				vJoined := false
+
+				forRange(r, scope.Get("rows"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("row", item)
+
+					if vJoined {
+						w.WriteString("; ")
+					}
+					vJoined = true
+					w.WriteString("<i>")
+					{
+						vJoined := false
+
+						forRange(r, scope.Get("row"), func(index interface{}, item interface{}) {
+							scope := acquireScope(r, scope)
+							scope.Set("$index", index)
+							scope.Set("x", item)
+
+							if vJoined {
+								w.WriteString(",")
+							}
+							vJoined = true
+							w.WriteString(interfaceToStr(scope.Get("x"), true))
+
+							releaseScope(r, scope)
+						})
+
+					}
+					w.WriteString("</i>")
+
+					releaseScope(r, scope)
+				})
+
+			}
+			w.WriteString("</p>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
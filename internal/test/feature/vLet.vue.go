@@ -0,0 +1,45 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:dbbc6bbf4794c7a100aa2ff04c4391e2
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vLet(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vLet", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vLet", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+
+	func(xscope *Scope) {
+		scope := extendScope(xscope, map[string]interface{}{
+			"total": interfaceToFunc(scope.Get("sum"))(r, options, scope.Get("a"), scope.Get("b")),
+		})
+		_ = scope
+		_tag(r, w, "div", true, &Options{
+			Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+				w.WriteString("<p>")
+				w.WriteString(interfaceToStr(scope.Get("total"), true))
+				w.WriteString("</p>")
+
+				if interfaceToBool(interfaceGreater(scope.Get("total"), 2)) {
+					w.WriteString("<p>")
+					w.WriteString(interfaceToStr(scope.Get("total"), true))
+					w.WriteString("</p>")
+				}
+			}},
+			P:          options,
+			Directives: options.Directives,
+			Scope:      scope,
+		})
+	}(scope)
+
+	hookDone()
+	return
+}
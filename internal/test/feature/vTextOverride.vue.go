@@ -0,0 +1,44 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:5182588d2770a2517b6f7ce325334964
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_vTextOverride(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("vTextOverride", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("vTextOverride", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "section", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<div>")
+			w.WriteString(interfaceToStr(scope.Get("x"), true))
+			w.WriteString("</div><div>")
+			w.WriteString(interfaceToStr(scope.Get("h")))
+			w.WriteString("</div>")
+			_tag(r, w, "p", false, &Options{
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString(interfaceToStr(scope.Get("x"), true))
+				}},
+				P: options,
+				Directives: []directive{
+					{Name: "v-show", Value: true, Arg: ""},
+				},
+				Scope: scope,
+			})
+			w.WriteString(interfaceToStr(scope.Get("h")))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
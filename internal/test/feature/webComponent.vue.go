@@ -0,0 +1,31 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:8fcb0d02438ac41ddd5d0852a109d950
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_webComponent(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("webComponent", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("webComponent", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<my-widget class=\"w\"" + mixinAttr(nil, []Attribute{
+				{Key: "camelCaseAttr", Val: "static"},
+			}, Props{orderKey: []string{"dataValue"}, data: map[string]interface{}{"dataValue": scope.Get("value")}}) + "></my-widget>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,50 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:864b5353b896a43f4332f2ac1b3e27e5
+
+package feature
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_wrapPage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("wrapPage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("wrapPage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_outerWrap(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					w.WriteString("<p>")
+					w.WriteString(interfaceToStr(scope.Get("msg"), true))
+					w.WriteString("</p>")
+				}},
+				P:     options,
+				Scope: scope,
+			})
+			xx_outerWrap(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+					xx_innerWrap(r, w, &Options{
+						Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+							w.WriteString(interfaceToStr(scope.Get("msg"), true))
+						}},
+						P:     options,
+						Scope: scope,
+					})
+				}},
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,36 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0450689eb62ee15ced3158315ec88247
+
+package inline
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_card(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("card", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("card", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"card"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<h3>")
+			w.WriteString(interfaceToStr(scope.Get("title"), true))
+			w.WriteString("</h3>")
+			_slot(r, w, &Options{
+				P:     options,
+				Scope: scope,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,98 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:b009e39ea62437dd2da720de172e5750
+
+package inline
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_inlinePage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("inlinePage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("inlinePage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			{
+				options := &Options{
+					PropsStyle: map[string]interface{}{"color": "red"},
+					Props:      Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": "Hello"}},
+					Class:      []string{"wide"},
+					Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+						forRange(r, scope.Get("items"), func(index interface{}, item interface{}) {
+							scope := acquireScope(r, scope)
+							scope.Set("i", index)
+							scope.Set("item", item)
+							{
+								options := &Options{
+									Props: Props{orderKey: []string{"text", "on"}, data: map[string]interface{}{"text": scope.Get("item"), "on": interfaceToStr(scope.Get("i")) == interfaceToStr(0)}},
+									P:     options,
+									Scope: scope,
+								}
+								if !r.canceled() && r.enter("badge", options) {
+									w, hookDone := r.hookComponent("badge", w)
+									scope := extendScope(r.Global, options.Props.data)
+									_ = scope
+									_tag(r, w, "span", true, &Options{
+										PropsClass: map[string]interface{}{"on": scope.Get("on")},
+										Class:      []string{"badge"},
+										Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+											w.WriteString(interfaceToStr(scope.Get("text"), true))
+										}},
+										P:          options,
+										Directives: options.Directives,
+										Scope:      scope,
+									})
+									hookDone()
+								}
+							}
+							releaseScope(r, scope)
+						})
+
+					}},
+					P:     options,
+					Scope: scope,
+				}
+				if !r.canceled() && r.enter("card", options) {
+					w, hookDone := r.hookComponent("card", w)
+					scope := extendScope(r.Global, options.Props.data)
+					_ = scope
+					_tag(r, w, "div", true, &Options{
+						Class: []string{"card"},
+						Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+							w.WriteString("<h3>")
+							w.WriteString(interfaceToStr(scope.Get("title"), true))
+							w.WriteString("</h3>")
+							_slot(r, w, &Options{
+								P:     options,
+								Scope: scope,
+							})
+						}},
+						P:          options,
+						Directives: options.Directives,
+						Scope:      scope,
+					})
+					hookDone()
+				}
+			}
+			w.WriteString("<ul>")
+			xx_treeNode(r, w, &Options{
+				Props: Props{orderKey: []string{"node"}, data: map[string]interface{}{"node": scope.Get("tree")}},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</ul>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
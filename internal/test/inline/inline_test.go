@@ -0,0 +1,118 @@
+// go-vue-ssr -src=./vue -to=./ -pkg=inline -inline=10
+// go-vue-ssr -src=./vue -to=./noinline -pkg=noinline
+
+package inline
+
+import (
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/internal/test/inline/noinline"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func props(n int) map[string]interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+	return map[string]interface{}{
+		"items": items,
+		"tree": map[string]interface{}{
+			"name": "root",
+			"children": []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b", "children": []interface{}{
+					map[string]interface{}{"name": "c"},
+				}},
+			},
+		},
+	}
+}
+
+func render(p map[string]interface{}) string {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("inlinePage", w, &Options{Props: NewProps(p)})
+	return w.Result()
+}
+
+func renderNoInline(p map[string]interface{}) string {
+	r := noinline.NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("inlinePage", w, &noinline.Options{Props: noinline.NewProps(p)})
+	return w.Result()
+}
+
+func TestInline(t *testing.T) {
+	p := props(2)
+	html := render(p)
+
+	want := `<div><div class="card wide" style="color: red;"><h3>Hello</h3>` +
+		`<span class="badge on">item-0</span><span class="badge">item-1</span></div>` +
+		`<ul><li>root<ul><li>a</li><li>b<ul><li>c</li></ul></li></ul></li></ul></div>`
+	if html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+	if noInline := renderNoInline(p); html != noInline {
+		t.Fatalf("inlined html = %s; not inlined: %s", html, noInline)
+	}
+
+	// 小组件被内联, 递归组件不会被内联
+	code, err := ioutil.ReadFile("inlinePage.vue.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range []string{"xx_badge(", "xx_card("} {
+		if strings.Contains(string(code), call) {
+			t.Fatalf("%s should be inlined", call)
+		}
+	}
+	if !strings.Contains(string(code), "xx_treeNode(") {
+		t.Fatalf("recursive component should not be inlined")
+	}
+}
+
+// 被内联的组件同样会调用OnComponentRendered
+func TestInlineComponentHook(t *testing.T) {
+	p := props(2)
+	hook := func(name string, html string) string {
+		return "[" + name + "]" + html + "[/" + name + "]"
+	}
+
+	c := NewRenderCreator()
+	c.OnComponentRendered = hook
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("inlinePage", w, &Options{Props: NewProps(p)})
+	html := w.Result()
+
+	nc := noinline.NewRenderCreator()
+	nc.OnComponentRendered = hook
+	nr := nc.NewRender()
+	nw := nr.NewWriter()
+	nr.Render("inlinePage", nw, &noinline.Options{Props: noinline.NewProps(p)})
+
+	if html != nw.Result() {
+		t.Fatalf("inlined html = %s; not inlined: %s", html, nw.Result())
+	}
+	if !strings.Contains(html, "[badge]<span") {
+		t.Fatalf("hook should be called for inlined badge: %s", html)
+	}
+}
+
+func BenchmarkInline(b *testing.B) {
+	p := props(1000)
+	b.Run("inline", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			render(p)
+		}
+	})
+	b.Run("noinline", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			renderNoInline(p)
+		}
+	})
+}
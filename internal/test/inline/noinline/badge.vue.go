@@ -0,0 +1,31 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:5a1f7784e79512cb6465184c4f27598d
+
+package noinline
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_badge(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("badge", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("badge", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "span", true, &Options{
+		PropsClass: map[string]interface{}{"on": scope.Get("on")},
+		Class:      []string{"badge"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("text"), true))
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,16 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+
+package noinline
+
+func NewRenderCreator() *RenderCreator {
+	r := newRenderCreator()
+	r.Components = map[string]ComponentFunc{
+		"badge":       xx_badge,
+		"card":        xx_card,
+		"inline-page": xx_inlinePage,
+		"inlinePage":  xx_inlinePage,
+		"tree-node":   xx_treeNode,
+		"treeNode":    xx_treeNode,
+	}
+	return r
+}
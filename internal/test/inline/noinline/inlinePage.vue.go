@@ -0,0 +1,57 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:7ba39eca91a5040c2c0c299c123f28a9
+
+package noinline
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_inlinePage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("inlinePage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("inlinePage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			xx_card(r, w, &Options{
+				PropsStyle: map[string]interface{}{"color": "red"},
+				Props:      Props{orderKey: []string{"title"}, data: map[string]interface{}{"title": "Hello"}},
+				Class:      []string{"wide"},
+				Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+					forRange(r, scope.Get("items"), func(index interface{}, item interface{}) {
+						scope := acquireScope(r, scope)
+						scope.Set("i", index)
+						scope.Set("item", item)
+						xx_badge(r, w, &Options{
+							Props: Props{orderKey: []string{"text", "on"}, data: map[string]interface{}{"text": scope.Get("item"), "on": interfaceToStr(scope.Get("i")) == interfaceToStr(0)}},
+							P:     options,
+							Scope: scope,
+						})
+						releaseScope(r, scope)
+					})
+
+				}},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("<ul>")
+			xx_treeNode(r, w, &Options{
+				Props: Props{orderKey: []string{"node"}, data: map[string]interface{}{"node": scope.Get("tree")}},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("</ul>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,47 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:c7f6b37ed78e156b4ec510d9e0818ed0
+
+package noinline
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_treeNode(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("treeNode", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("treeNode", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	_tag(r, w, "li", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString(interfaceToStr(scope.Get("node", "name"), true))
+
+			if interfaceToBool(scope.Get("node", "children")) {
+				w.WriteString("<ul>")
+
+				forRange(r, scope.Get("node", "children"), func(index interface{}, item interface{}) {
+					scope := acquireScope(r, scope)
+					scope.Set("$index", index)
+					scope.Set("c", item)
+					xx_treeNode(r, w, &Options{
+						Props: Props{orderKey: []string{"node"}, data: map[string]interface{}{"node": scope.Get("c")}},
+						P:     options,
+						Scope: scope,
+					})
+					releaseScope(r, scope)
+				})
+
+				w.WriteString("</ul>")
+			}
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,14 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+
+package readable
+
+func NewRenderCreator() *RenderCreator {
+	r := newRenderCreator()
+	r.Components = map[string]ComponentFunc{
+		"readable-item": xx_readableItem,
+		"readable-page": xx_readablePage,
+		"readableItem":  xx_readableItem,
+		"readablePage":  xx_readablePage,
+	}
+	return r
+}
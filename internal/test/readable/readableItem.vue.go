@@ -0,0 +1,32 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:f54d9cd0cc3a5f5a033f735bb625d154
+
+package readable
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_readableItem(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("readableItem", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("readableItem", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	w.WriteString("\n")
+	_tag(r, w, "section", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("<span>")
+			w.WriteString(interfaceToStr(scope.Get("name"), true))
+			w.WriteString("</span>")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
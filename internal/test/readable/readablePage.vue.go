@@ -0,0 +1,57 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:0b58f73488ce5eb882b7f567527c650d
+
+package readable
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_readablePage(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("readablePage", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("readablePage", w)
+	scope := extendScope(r.Global, options.Props.data)
+	_ = scope
+	w.WriteString("\n")
+	_tag(r, w, "div", true, &Options{
+		Class: []string{"page"},
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			w.WriteString("\n<h1>")
+			w.WriteString(interfaceToStr(scope.Get("title"), true))
+			w.WriteString("</h1>\n<ul>")
+
+			forRange(r, scope.Get("items"), func(index interface{}, item interface{}) {
+				scope := acquireScope(r, scope)
+				scope.Set("$index", index)
+				scope.Set("item", item)
+				w.WriteString("\n<li>")
+				w.WriteString(interfaceToStr(scope.Get("item"), true))
+				w.WriteString("</li>")
+				releaseScope(r, scope)
+			})
+
+			w.WriteString("\n</ul>\n<p>text <b>bold</b><i>it</i></p>\n<pre>")
+
+			keepLeadingNewline(r, w, func(w Writer) {
+				w.WriteString("  keep\n    indent")
+			})
+
+			w.WriteString("</pre>")
+			xx_readableItem(r, w, &Options{
+				Props: Props{orderKey: []string{"name"}, data: map[string]interface{}{"name": scope.Get("title")}},
+				P:     options,
+				Scope: scope,
+			})
+			w.WriteString("\n")
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      scope,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,28 @@
+// go-vue-ssr -src=./vue -to=./ -pkg=readable -readable
+
+package readable
+
+import (
+	"testing"
+)
+
+// 块级元素前后只有一个换行, 没有缩进, 块级元素中开头与结尾的空白被去掉, <pre>中的空白原样输出
+func TestReadable(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("readablePage", w, &Options{Props: NewProps(map[string]interface{}{
+		"title": "Hi",
+		"items": []interface{}{"a", "b"},
+	})})
+
+	want := "\n<div class=\"page\">" +
+		"\n<h1>Hi</h1>" +
+		"\n<ul>\n<li>a</li>\n<li>b</li>\n</ul>" +
+		"\n<p>text <b>bold</b><i>it</i></p>" +
+		"\n<pre>  keep\n    indent</pre>" +
+		"\n<section><span>Hi</span></section>" +
+		"\n</div>"
+	if w.Result() != want {
+		t.Fatalf("html = %q; want: %q", w.Result(), want)
+	}
+}
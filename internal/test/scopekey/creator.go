@@ -0,0 +1,13 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+
+package scopekey
+
+func NewRenderCreator() *RenderCreator {
+	r := newRenderCreator()
+	r.Components = map[string]ComponentFunc{
+		"list":       xx_list,
+		"slot-child": xx_slotChild,
+		"slotChild":  xx_slotChild,
+	}
+	return r
+}
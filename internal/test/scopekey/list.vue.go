@@ -0,0 +1,58 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:7e525a34129ad14abb35126e93437df2
+
+package scopekey
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_list(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("list", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("list", w)
+	data := extendScope(r.Global, options.Props.data)
+	_ = data
+	_tag(r, w, "ul", true, &Options{
+		PropsClass: data.Get("cls"),
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+
+			forRange(r, data.Get("list"), func(index interface{}, item interface{}) {
+				data := acquireScope(r, data)
+				data.Set("index", index)
+				data.Set("item", item)
+
+				func(xscope *Scope) {
+					data := extendScope(xscope, map[string]interface{}{
+						"label": data.Get("item", "name"),
+					})
+					_ = data
+					w.WriteString("<li" + mixinAttr(nil, nil, Props{orderKey: []string{"id"}, data: map[string]interface{}{"id": data.Get("index")}}) + ">")
+					w.WriteString(interfaceToStr(execFilter(r, "raw", data.Get("label")), true))
+					w.WriteString("</li>")
+				}(data)
+
+				releaseScope(r, data)
+			})
+
+			xx_slotChild(r, w, &Options{
+				Slots: map[string]NamedSlotFunc{"item": func(w Writer, props Props) {
+					data := acquireScope(r, data)
+					data.Set("slotProps", props.Map())
+					w.WriteString(interfaceToStr(data.Get("slotProps", "a"), true))
+					releaseScope(r, data)
+				}},
+				P:     options,
+				Scope: data,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      data,
+	})
+	hookDone()
+	return
+}
@@ -0,0 +1,25 @@
+// go-vue-ssr -src=./vue -to=./ -pkg=scopekey -scope-key=data
+
+package scopekey
+
+import (
+	"testing"
+)
+
+// 修改了作用域变量的名字, 生成的代码可以正常渲染
+func TestScopeKey(t *testing.T) {
+	r := NewRenderCreator().NewRender()
+	w := r.NewWriter()
+	r.Render("list", w, &Options{Props: NewProps(map[string]interface{}{
+		"cls": "list",
+		"list": []interface{}{
+			map[string]interface{}{"name": "<a>"},
+			map[string]interface{}{"name": "b"},
+		},
+	})})
+
+	want := `<ul class="list"><li id="0"><a></li><li id="1">b</li><div>x</div></ul>`
+	if w.Result() != want {
+		t.Fatalf("html = %s; want: %s", w.Result(), want)
+	}
+}
@@ -0,0 +1,36 @@
+// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr
+// src_hash:319031f49dfab2e2ebe66f06b86b65a9
+
+package scopekey
+
+import (
+	"strings"
+)
+
+type _ strings.Builder
+
+func xx_slotChild(r *Render, w Writer, options *Options) {
+	if r.canceled() || !r.enter("slotChild", options) {
+		return
+	}
+	w, hookDone := r.hookComponent("slotChild", w)
+	data := extendScope(r.Global, options.Props.data)
+	_ = data
+	_tag(r, w, "div", true, &Options{
+		Slots: map[string]NamedSlotFunc{"default": func(w Writer, props Props) {
+			_slot(r, w, &Options{
+				Props: Props{orderKey: []string{"a"}, data: map[string]interface{}{"a": "x"}},
+				Attrs: []Attribute{
+					{Key: "name", Val: "item"},
+				},
+				P:     options,
+				Scope: data,
+			})
+		}},
+		P:          options,
+		Directives: options.Directives,
+		Scope:      data,
+	})
+	hookDone()
+	return
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/urfave/cli/v2"
 	"github.com/zbysir/go-vue-ssr/internal/pkg/log"
 	"github.com/zbysir/go-vue-ssr/internal/pkg/signal"
@@ -30,6 +31,71 @@ func main() {
 			Name:  "pkg",
 			Usage: "pkg name",
 		},
+		&cli.StringSliceFlag{
+			Name:  "entry",
+			Usage: "Entry components, only components reachable from entry will be compiled",
+		},
+		&cli.StringSliceFlag{
+			Name:  "code",
+			Usage: "Components implemented in go code, register them by RenderCreator.Component",
+		},
+		&cli.StringSliceFlag{
+			Name:  "build-flag",
+			Usage: "Build flags for v-build-if",
+		},
+		&cli.BoolFlag{
+			Name:  "sanitize-url",
+			Usage: "Sanitize urls bound to href/src/action",
+		},
+		&cli.IntFlag{
+			Name:  "inline",
+			Usage: "Inline components with at most n nodes into their callers",
+		},
+		&cli.BoolFlag{
+			Name:  "canonical-attrs",
+			Usage: "Emit attributes in a canonical order instead of source order",
+		},
+		&cli.BoolFlag{
+			Name:  "strict-attrs",
+			Usage: "Fail on duplicate attributes instead of warning",
+		},
+		&cli.BoolFlag{
+			Name:  "keep-comments",
+			Usage: "Keep html comments in templates, comments are emitted verbatim",
+		},
+		&cli.BoolFlag{
+			Name:  "readable",
+			Usage: "Keep a newline around block elements in the output html, for debugging",
+		},
+		&cli.BoolFlag{
+			Name:  "xhtml",
+			Usage: "Emit boolean attributes in XHTML form, like disabled=\"disabled\"",
+		},
+		&cli.BoolFlag{
+			Name:  "empty-bool",
+			Usage: "Render boolean values in interpolations as empty strings instead of true/false",
+		},
+		&cli.BoolFlag{
+			Name:  "trim-interpolation",
+			Usage: "Trim whitespace around an interpolation that is the only content of a block element",
+		},
+		&cli.BoolFlag{
+			Name:  "extract-styles",
+			Usage: "Move inline style attributes into a collected stylesheet, for CSP without unsafe-inline",
+		},
+		&cli.BoolFlag{
+			Name:  "cache-expr",
+			Usage: "Cache the value of nested path expressions (like user.profile.name) within a render",
+		},
+		&cli.StringFlag{
+			Name:  "scope-key",
+			Usage: "Name of the scope variable in the generated code, change it when it conflicts with a name used in templates",
+		},
+		&cli.StringFlag{
+			Name:  "ext",
+			Value: ".vue",
+			Usage: "Extension of template files",
+		},
 		&cli.BoolFlag{
 			Name:  "watch",
 			Usage: "watch file and rebuild",
@@ -43,17 +109,50 @@ func main() {
 		}
 		to := c.String("to")
 		pkg := c.String("pkg")
+		entry := c.StringSlice("entry")
+
+		compiler := vuessr.NewCompiler()
+		for _, name := range c.StringSlice("code") {
+			compiler.AddCodeComponent(name)
+		}
+		compiler.SanitizeURL = c.Bool("sanitize-url")
+		compiler.StrictAttrs = c.Bool("strict-attrs")
+		compiler.CanonicalAttrs = c.Bool("canonical-attrs")
+		compiler.InlineSize = c.Int("inline")
+		compiler.Ext = c.String("ext")
+		compiler.KeepComments = c.Bool("keep-comments")
+		compiler.CacheExpr = c.Bool("cache-expr")
+		compiler.XHTML = c.Bool("xhtml")
+		compiler.EmptyBool = c.Bool("empty-bool")
+		compiler.TrimInterpolation = c.Bool("trim-interpolation")
+		compiler.ExtractStyles = c.Bool("extract-styles")
+		if key := c.String("scope-key"); key != "" {
+			err = vuessr.CheckScopeKey(key)
+			if err != nil {
+				return fmt.Errorf("-scope-key: %v", err)
+			}
+			compiler.ScopeKey = key
+		}
+		if c.Bool("readable") {
+			compiler.Whitespace = vuessr.WhitespaceReadable
+		}
+		if flags := c.StringSlice("build-flag"); len(flags) != 0 {
+			compiler.BuildFlags = map[string]bool{}
+			for _, f := range flags {
+				compiler.BuildFlags[f] = true
+			}
+		}
 
 		if c.Bool("watch") {
 			ctx, cancel := signal.NewTermContext()
 			defer cancel()
 
-			err = vuessr.GenAllFileWithWatch(ctx, src, to, pkg)
+			err = compiler.GenAllFileWithWatch(ctx, src, to, pkg, entry...)
 			if err != nil {
 				return
 			}
 		} else {
-			err = vuessr.GenAllFile(src, to, pkg)
+			err = compiler.GenAllFile(src, to, pkg, entry)
 			if err != nil {
 				return
 			}
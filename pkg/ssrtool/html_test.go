@@ -9,3 +9,30 @@ func TestFormat(t *testing.T) {
 	//bs,_:=json.Marshal(s)
 	t.Logf("'%s'", s)
 }
+
+func TestSelectHtml(t *testing.T) {
+	src := `<!doctype html><html><head><title>x</title></head><body><div id="header">h</div><div id="main"><p ref="content">a &amp; b</p></div></body></html>`
+
+	s, err := SelectHtml(src, "#main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<div id="main"><p ref="content">a &amp; b</p></div>`
+	if s != want {
+		t.Fatalf("s = %s; want: %s", s, want)
+	}
+
+	s, err = SelectHtml(src, "content")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `<p ref="content">a &amp; b</p>`
+	if s != want {
+		t.Fatalf("s = %s; want: %s", s, want)
+	}
+
+	_, err = SelectHtml(src, "#footer")
+	if err == nil {
+		t.Fatal("want err for not exist node")
+	}
+}
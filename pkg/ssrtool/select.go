@@ -0,0 +1,60 @@
+package ssrtool
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/internal/pkg/html"
+	"strings"
+)
+
+// SelectHtml 从渲染结果中取出一个子节点的html, 用于只返回页面中的一部分(如ajax局部刷新)
+// selector 支持两种写法:
+//   #main: 选择id为main的节点
+//   main: 选择ref为main的节点
+func SelectHtml(src string, selector string) (out string, err error) {
+	key := "ref"
+	val := selector
+	if strings.HasPrefix(selector, "#") {
+		key = "id"
+		val = selector[1:]
+	}
+
+	root, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return
+	}
+
+	node := findNodeByAttr(root, key, val)
+	if node == nil {
+		err = fmt.Errorf("can't find node by selector: %s", selector)
+		return
+	}
+
+	var b bytes.Buffer
+	err = html.Render(&b, node)
+	if err != nil {
+		return
+	}
+
+	out = b.String()
+	return
+}
+
+// 深度优先查找第一个属性匹配的节点
+func findNodeByAttr(n *html.Node, key, val string) *html.Node {
+	if n.Type == html.ElementNode {
+		for _, a := range n.Attr {
+			if a.Key == key && a.Val == val {
+				return n
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if f := findNodeByAttr(c, key, val); f != nil {
+			return f
+		}
+	}
+
+	return nil
+}
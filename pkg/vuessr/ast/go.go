@@ -5,12 +5,29 @@ import (
 	"github.com/robertkrimen/otto/ast"
 	"github.com/robertkrimen/otto/parser"
 	"github.com/robertkrimen/otto/token"
+	"math"
 	"strings"
 )
 
 // 生成go代码
 // dataKey: 默认为options.data
 func Js2Go(code string, scopeKey string) (goCode string, err error) {
+	return goGen{scopeKey: scopeKey}.js2Go(code)
+}
+
+// 和Js2Go一样, 但多级路径的取值(如user.profile.name)使用cachedGet, 结果在一次渲染中缓存, 见Compiler.CacheExpr
+func Js2GoCached(code string, scopeKey string) (goCode string, err error) {
+	return goGen{scopeKey: scopeKey, cache: true}.js2Go(code)
+}
+
+// 生成go代码的设置
+type goGen struct {
+	scopeKey string
+	cache    bool
+}
+
+func (g goGen) js2Go(code string) (goCode string, err error) {
+	src := code
 	// 用括号包裹的原因是让"{x: 1}"这样的语法解析成对象, 而不是label
 	code = fmt.Sprintf("(%s)", code)
 
@@ -20,24 +37,32 @@ func Js2Go(code string, scopeKey string) (goCode string, err error) {
 		return
 	}
 
-	goCode = genGoCodeByNode(p.Body[0], scopeKey)
+	// 渲染是只读的, 赋值(如v-if="x = 1")一般是把==写成了=
+	walk(p.Body[0], func(node ast.Node) {
+		if _, ok := node.(*ast.AssignExpression); ok && err == nil {
+			err = fmt.Errorf("assignment is not allowed in expression %q, did you mean \"==\"?", src)
+		}
+	})
+	if err != nil {
+		return
+	}
+
+	goCode = g.genGoCodeByNode(p.Body[0])
 	return
 }
 
-func genGoCodeByNode(node ast.Node, scopeKey string) (goCode string) {
+func (g goGen) genGoCodeByNode(node ast.Node) (goCode string) {
 	switch t := node.(type) {
 
 	case *ast.ExpressionStatement:
-		return genGoCodeByNode(t.Expression, scopeKey)
+		return g.genGoCodeByNode(t.Expression)
 	case *ast.Identifier:
-		return fmt.Sprintf(`%s.Get("%s")`, scopeKey, t.Name)
+		return fmt.Sprintf(`%s.Get("%s")`, g.scopeKey, t.Name)
 	case *ast.DotExpression:
-		root, keys := lookExpress(t, scopeKey)
-		return fmt.Sprintf(`%s.Get(%s)`, root, strings.Join(keys, ", "))
+		return g.getCode(g.lookExpress(t))
 	case *ast.BracketExpression:
 		// a[b]
-		root, keys := lookExpress(t, scopeKey)
-		return fmt.Sprintf(`%s.Get(%s)`, root, strings.Join(keys, ", "))
+		return g.getCode(g.lookExpress(t))
 	case *ast.StringLiteral:
 		return fmt.Sprintf(`"%s"`, t.Value)
 	case *ast.NumberLiteral:
@@ -47,8 +72,8 @@ func genGoCodeByNode(node ast.Node, scopeKey string) (goCode string) {
 	case *ast.NullLiteral:
 		return fmt.Sprintf("%v", "nil")
 	case *ast.BinaryExpression:
-		left := genGoCodeByNode(t.Left, scopeKey)
-		right := genGoCodeByNode(t.Right, scopeKey)
+		left := g.genGoCodeByNode(t.Left)
+		right := g.genGoCodeByNode(t.Right)
 		o := t.Operator
 		switch o {
 		case token.STRICT_EQUAL, token.EQUAL:
@@ -75,7 +100,7 @@ func genGoCodeByNode(node ast.Node, scopeKey string) (goCode string) {
 		}
 
 	case *ast.UnaryExpression:
-		arg := genGoCodeByNode(t.Operand, scopeKey)
+		arg := g.genGoCodeByNode(t.Operand)
 		switch t.Operator {
 		case token.NOT:
 			return fmt.Sprintf(`%sinterfaceToBool(%s)`, t.Operator, arg)
@@ -103,30 +128,30 @@ func genGoCodeByNode(node ast.Node, scopeKey string) (goCode string) {
 				panic(fmt.Sprintf("bad Value kind of ObjectLiteral: %v", v.Kind))
 			}
 
-			valueCode := genGoCodeByNode(v.Value, scopeKey)
+			valueCode := g.genGoCodeByNode(v.Value)
 			mapCode += fmt.Sprintf(`%s: %s,`, k, valueCode)
 		}
 		mapCode += "}"
 		return mapCode
 	case *ast.CallExpression:
-		funcName := genGoCodeByNode(t.Callee, scopeKey)
+		funcName := g.genGoCodeByNode(t.Callee)
 
 		args := make([]string, len(t.ArgumentList))
 		for i, v := range t.ArgumentList {
-			args[i] = genGoCodeByNode(v, scopeKey)
+			args[i] = g.genGoCodeByNode(v)
 		}
 		return fmt.Sprintf(`interfaceToFunc(%s)(r, options, %s)`, funcName, strings.Join(args, ","))
 	case *ast.ArrayLiteral:
 		args := make([]string, len(t.Value))
 		for i, v := range t.Value {
-			args[i] = genGoCodeByNode(v, scopeKey)
+			args[i] = g.genGoCodeByNode(v)
 		}
 		return fmt.Sprintf(`[]interface{}{%s}`, strings.Join(args, ","))
 	case *ast.ConditionalExpression:
 		// 三元运算
-		consequent := genGoCodeByNode(t.Consequent, scopeKey)
-		alternate := genGoCodeByNode(t.Alternate, scopeKey)
-		test := genGoCodeByNode(t.Test, scopeKey)
+		consequent := g.genGoCodeByNode(t.Consequent)
+		alternate := g.genGoCodeByNode(t.Alternate)
+		test := g.genGoCodeByNode(t.Test)
 
 		return fmt.Sprintf(`func() interface{} {if interfaceToBool(%s){return %s};return %s}()`, test, consequent, alternate)
 
@@ -138,26 +163,313 @@ func genGoCodeByNode(node ast.Node, scopeKey string) (goCode string) {
 	return
 }
 
+// 常量折叠: 如果表达式只由字面量组成(如 2 * 3, 'a' + 'b'), 则在编译期计算出结果.
+// ok为false表示不能在编译期计算, 如引用了变量, 除以0, 结果溢出等情况, 这些情况交给运行时处理.
+func ConstFold(code string) (value interface{}, ok bool) {
+	code = fmt.Sprintf("(%s)", code)
+
+	p, err := parser.ParseFile(nil, "", code, 0)
+	if err != nil {
+		return
+	}
+
+	if len(p.Body) != 1 {
+		return
+	}
+	s, isExp := p.Body[0].(*ast.ExpressionStatement)
+	if !isExp {
+		return
+	}
+	return constFold(s.Expression)
+}
+
+func constFold(node ast.Node) (value interface{}, ok bool) {
+	switch t := node.(type) {
+	case *ast.StringLiteral:
+		return t.Value, true
+	case *ast.NumberLiteral:
+		switch v := t.Value.(type) {
+		case int64:
+			return float64(v), true
+		case float64:
+			return v, true
+		}
+	case *ast.UnaryExpression:
+		if t.Operator != token.MINUS {
+			return
+		}
+		v, vOk := constFold(t.Operand)
+		if f, isNum := v.(float64); vOk && isNum {
+			return -f, true
+		}
+	case *ast.BinaryExpression:
+		left, lOk := constFold(t.Left)
+		right, rOk := constFold(t.Right)
+		if !lOk || !rOk {
+			return
+		}
+
+		l, lIsNum := left.(float64)
+		r, rIsNum := right.(float64)
+		var f float64
+		switch t.Operator {
+		case token.PLUS:
+			if !lIsNum || !rIsNum {
+				// 和运行时的interfaceAdd一样, 只要有一方不是数字就是字符串拼接
+				return fmt.Sprintf("%v%v", left, right), true
+			}
+			f = l + r
+		case token.MINUS:
+			f = l - r
+		case token.MULTIPLY:
+			f = l * r
+		case token.SLASH:
+			if r == 0 {
+				return
+			}
+			f = l / r
+		default:
+			return
+		}
+		if !lIsNum || !rIsNum || math.IsInf(f, 0) || math.IsNaN(f) {
+			return
+		}
+		return f, true
+	}
+
+	return
+}
+
+// 返回表达式中引用到的(作用域中的)变量名, 如a.b + c[d]会返回[a, c, d]
+func Identifiers(code string) (names []string, err error) {
+	code = fmt.Sprintf("(%s)", code)
+
+	p, err := parser.ParseFile(nil, "", code, 0)
+	if err != nil {
+		err = fmt.Errorf("GetAst err: %w, code:%s", err, code)
+		return
+	}
+
+	walk(p.Body[0], func(node ast.Node) {
+		if t, ok := node.(*ast.Identifier); ok {
+			names = append(names, t.Name)
+		}
+	})
+	return
+}
+
+// 返回表达式中调用的方法名, 如f(a) + list.filter(g)会返回[f, filter]
+// 对于a.b()这样的调用, 方法名是b
+func Calls(code string) (names []string, err error) {
+	code = fmt.Sprintf("(%s)", code)
+
+	p, err := parser.ParseFile(nil, "", code, 0)
+	if err != nil {
+		err = fmt.Errorf("GetAst err: %w, code:%s", err, code)
+		return
+	}
+
+	walk(p.Body[0], func(node ast.Node) {
+		t, ok := node.(*ast.CallExpression)
+		if !ok {
+			return
+		}
+		switch c := t.Callee.(type) {
+		case *ast.Identifier:
+			names = append(names, c.Name)
+		case *ast.DotExpression:
+			names = append(names, c.Identifier.Name)
+		case *ast.BracketExpression:
+			// a['b']()
+			if m, ok := c.Member.(*ast.StringLiteral); ok {
+				names = append(names, m.Value)
+			} else {
+				// 无法在编译期确定方法名
+				names = append(names, "")
+			}
+		default:
+			names = append(names, "")
+		}
+	})
+	return
+}
+
+// 返回表达式中读取的变量名, 如a.b + f(c[d])会返回[a, c, d]
+// 直接调用的方法名(如f)不是变量, 由Calls返回
+func Vars(code string) (names []string, err error) {
+	code = fmt.Sprintf("(%s)", code)
+
+	p, err := parser.ParseFile(nil, "", code, 0)
+	if err != nil {
+		err = fmt.Errorf("GetAst err: %w, code:%s", err, code)
+		return
+	}
+
+	callee := map[ast.Node]bool{}
+	walk(p.Body[0], func(node ast.Node) {
+		switch t := node.(type) {
+		case *ast.CallExpression:
+			if id, ok := t.Callee.(*ast.Identifier); ok {
+				callee[id] = true
+			}
+		case *ast.Identifier:
+			if !callee[t] {
+				names = append(names, t.Name)
+			}
+		}
+	})
+	return
+}
+
+// 检查表达式的复杂度: 语法树的深度不能超过maxDepth, 节点数不能超过maxNodes, 为0时不限制
+// 括号的嵌套层数会在解析之前检查, 避免过深的表达式在解析时就耗尽资源
+func CheckComplexity(code string, maxDepth, maxNodes int) (err error) {
+	if maxDepth > 0 {
+		if d := nestDepth(code); d > maxDepth {
+			return fmt.Errorf("expression is too deeply nested (%d levels, max %d)", d, maxDepth)
+		}
+	}
+
+	code = fmt.Sprintf("(%s)", code)
+	p, err := parser.ParseFile(nil, "", code, 0)
+	if err != nil {
+		err = fmt.Errorf("GetAst err: %w, code:%s", err, code)
+		return
+	}
+
+	depth, nodes := 0, 0
+	walkDepth(p.Body[0], 0, func(node ast.Node, d int) {
+		nodes++
+		if d > depth {
+			depth = d
+		}
+	})
+	// 不计算最外层的ExpressionStatement
+	nodes--
+	if maxDepth > 0 && depth > maxDepth {
+		return fmt.Errorf("expression is too deeply nested (%d levels, max %d)", depth, maxDepth)
+	}
+	if maxNodes > 0 && nodes > maxNodes {
+		return fmt.Errorf("expression is too large (%d nodes, max %d)", nodes, maxNodes)
+	}
+	return nil
+}
+
+// 括号(包括[]与{})的最大嵌套层数, 字符串中的括号不计算
+func nestDepth(code string) (max int) {
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, c := range code {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+			if depth > max {
+				max = depth
+			}
+		case ')', ']', '}':
+			depth--
+		}
+	}
+	return
+}
+
+// 遍历表达式中的所有节点
+// 注意a.b中的b不是变量, 不会被遍历
+func walk(node ast.Node, f func(node ast.Node)) {
+	walkDepth(node, 0, func(node ast.Node, depth int) {
+		f(node)
+	})
+}
+
+// 同walk, depth是节点的深度, 最外层节点的深度为0
+func walkDepth(node ast.Node, depth int, f func(node ast.Node, depth int)) {
+	f(node, depth)
+
+	depth++
+	switch t := node.(type) {
+	case *ast.ExpressionStatement:
+		walkDepth(t.Expression, depth-1, f)
+	case *ast.DotExpression:
+		walkDepth(t.Left, depth, f)
+	case *ast.BracketExpression:
+		walkDepth(t.Left, depth, f)
+		walkDepth(t.Member, depth, f)
+	case *ast.BinaryExpression:
+		walkDepth(t.Left, depth, f)
+		walkDepth(t.Right, depth, f)
+	case *ast.UnaryExpression:
+		walkDepth(t.Operand, depth, f)
+	case *ast.ObjectLiteral:
+		for _, v := range t.Value {
+			walkDepth(v.Value, depth, f)
+		}
+	case *ast.CallExpression:
+		walkDepth(t.Callee, depth, f)
+		for _, v := range t.ArgumentList {
+			walkDepth(v, depth, f)
+		}
+	case *ast.ArrayLiteral:
+		for _, v := range t.Value {
+			walkDepth(v, depth, f)
+		}
+	case *ast.ConditionalExpression:
+		walkDepth(t.Test, depth, f)
+		walkDepth(t.Consequent, depth, f)
+		walkDepth(t.Alternate, depth, f)
+	}
+}
+
+// 读取路径的代码, 开启缓存时作用域中2到4级且key都是常量的路径使用cachedGet读取, 其他的(如a[b])每次都需要计算
+func (g goGen) getCode(root string, keys []string) string {
+	if g.cache && root == g.scopeKey && len(keys) >= 2 && len(keys) <= 4 && constKeys(keys) {
+		return fmt.Sprintf(`cachedGet(r, %s, %s)`, root, strings.Join(keys, ", "))
+	}
+	return fmt.Sprintf(`%s.Get(%s)`, root, strings.Join(keys, ", "))
+}
+
+func constKeys(keys []string) bool {
+	for _, k := range keys {
+		if !strings.HasPrefix(k, `"`) {
+			return false
+		}
+	}
+	return true
+}
+
 // 读取值
 // 将a.b.c解析成 root 和keys
 // 如a.b.c, root: this, keys: [a ,b ,c]
 // 如"a".length, root: "a", keys: [length]
-func lookExpress(e ast.Expression, scopeKey string) (root string, keys []string) {
+func (g goGen) lookExpress(e ast.Expression) (root string, keys []string) {
 	switch r := e.(type) {
 	case *ast.DotExpression:
 		// a.b 中的b
 		currKey := fmt.Sprintf(`"%s"`, r.Identifier.Name)
-		root, keys = lookExpress(r.Left, scopeKey)
+		root, keys = g.lookExpress(r.Left)
 		keys = append(keys, currKey)
 	case *ast.Identifier:
 		// a.b 中的a
 		// 使用dataKey读取变量
-		root = scopeKey
+		root = g.scopeKey
 		keys = []string{fmt.Sprintf(`"%s"`, r.Name)}
 	case *ast.ObjectLiteral:
-		root = genGoCodeByNode(r, scopeKey)
+		root = g.genGoCodeByNode(r)
 	case *ast.BinaryExpression:
-		root = genGoCodeByNode(r, scopeKey)
+		root = g.genGoCodeByNode(r)
 	case *ast.BracketExpression:
 		var currKey string
 		switch m := r.Member.(type) {
@@ -165,14 +477,21 @@ func lookExpress(e ast.Expression, scopeKey string) (root string, keys []string)
 			// a['b']
 			// 也可以走default语句, 但这是fastPath, 可以少调用interfaceToStr函数
 			currKey = fmt.Sprintf(`"%s"`, m.Value)
+		case *ast.NumberLiteral:
+			// a[0], 同样是fastPath
+			if i, ok := m.Value.(int64); ok {
+				currKey = fmt.Sprintf(`"%d"`, i)
+			} else {
+				currKey = fmt.Sprintf(`interfaceToStr(%v)`, m.Value)
+			}
 		default:
 			// a[b]
 			// a[a+1]
 			// ... 各种表达式
-			currKey = fmt.Sprintf(`interfaceToStr(%s)`, genGoCodeByNode(r.Member, scopeKey))
+			currKey = fmt.Sprintf(`interfaceToStr(%s)`, g.genGoCodeByNode(r.Member))
 		}
 
-		root, keys = lookExpress(r.Left, scopeKey)
+		root, keys = g.lookExpress(r.Left)
 		keys = append(keys, currKey)
 	default:
 		panic(fmt.Sprintf("bad type for lookExpress: %T, %s", r, r))
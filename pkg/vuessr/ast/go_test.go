@@ -1,6 +1,9 @@
 package ast
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestObject(t *testing.T) {
 	gocode, err := Js2Go(`{a+1: 1}[c]`, "this")
@@ -96,3 +99,117 @@ func TestAll(t *testing.T) {
 	t.Logf("%+v", gocode)
 
 }
+
+func TestIdentifiers(t *testing.T) {
+	names, err := Identifiers(`item.id + list[index].name + f(a, "b")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "item,list,index,f,a"
+	if strings.Join(names, ",") != want {
+		t.Fatalf("names = %v; want: %s", names, want)
+	}
+}
+
+func TestConstFold(t *testing.T) {
+	cases := []struct {
+		code string
+		want interface{}
+		ok   bool
+	}{
+		{`2 * 3`, float64(6), true},
+		{`(1 + 2) * 3 - 1`, float64(8), true},
+		{`7 / 2`, 3.5, true},
+		{`'a' + 'b'`, "ab", true},
+		{`1 + 'a'`, "1a", true},
+		{`-1`, float64(-1), true},
+		{`1 / 0`, nil, false},
+		{`1e308 * 10`, nil, false},
+		{`'a' * 2`, nil, false},
+		{`a + 1`, nil, false},
+		{`f(1)`, nil, false},
+	}
+
+	for _, c := range cases {
+		v, ok := ConstFold(c.code)
+		if ok != c.ok || v != c.want {
+			t.Fatalf("%s: ConstFold() = %v, %v; want: %v, %v", c.code, v, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestBracketIndex(t *testing.T) {
+	cases := []struct {
+		code string
+		want string
+	}{
+		{`items[0].name`, `scope.Get("items", "0", "name")`},
+		{`matrix[i][j]`, `scope.Get("matrix", interfaceToStr(scope.Get("i")), interfaceToStr(scope.Get("j")))`},
+		{`matrix[1][j].name`, `scope.Get("matrix", "1", interfaceToStr(scope.Get("j")), "name")`},
+	}
+
+	for _, c := range cases {
+		gocode, err := Js2Go(c.code, "scope")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gocode != c.want {
+			t.Fatalf("%s: gocode = %s; want: %s", c.code, gocode, c.want)
+		}
+	}
+}
+
+func TestCalls(t *testing.T) {
+	names, err := Calls(`f(a) + list.filter(g)[0] + h(i(1))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "f,filter,h,i"
+	if strings.Join(names, ",") != want {
+		t.Fatalf("names = %v; want: %s", names, want)
+	}
+}
+
+func TestVars(t *testing.T) {
+	names, err := Vars(`a.b + f(c[d]) + list.filter(g) + {k: v}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a,c,d,list,g,v"
+	if strings.Join(names, ",") != want {
+		t.Fatalf("names = %v; want: %s", names, want)
+	}
+}
+
+func TestCheckComplexity(t *testing.T) {
+	if err := CheckComplexity(`a.b + f(c, [1, 2]) ? "(((" : d`, 4, 12); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		code string
+		want string
+	}{
+		// 在解析之前就会被拒绝
+		{strings.Repeat("(", 1000) + "a" + strings.Repeat(")", 1000), "too deeply nested (1000 levels, max 4)"},
+		{"a.b.c.d.e.f", "too deeply nested (5 levels, max 4)"},
+		{"[a, b, c, d, e, f, g, h, i, j, k, l]", "too large (13 nodes, max 12)"},
+	}
+	for _, tc := range cases {
+		err := CheckComplexity(tc.code, 4, 12)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: err = %v; want: %s", tc.code, err, tc.want)
+		}
+	}
+}
+
+func TestAssignNotAllowed(t *testing.T) {
+	for _, code := range []string{"x = 1", "a && (b = 1)", "x += 1"} {
+		_, err := Js2Go(code, "this")
+		if err == nil || !strings.Contains(err.Error(), "assignment is not allowed") {
+			t.Fatalf("%s: err = %v; want assignment error", code, err)
+		}
+	}
+	if _, err := Js2Go("x == 1", "this"); err != nil {
+		t.Fatal(err)
+	}
+}
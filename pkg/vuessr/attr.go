@@ -2,17 +2,18 @@ package vuessr
 
 import (
 	"fmt"
-	"github.com/zbysir/go-vue-ssr/internal/pkg/log"
-	"github.com/zbysir/go-vue-ssr/pkg/vuessr/ast"
+	"html"
+	"sort"
+	"strconv"
 	"strings"
 )
 
-func genPropsClassCode(classJs string) string {
+func genPropsClassCode(classJs string, scopeKey string, cacheExpr bool) string {
 	if classJs == "" {
 		return "nil"
 	}
 
-	code, err := ast.Js2Go(classJs, ScopeKey)
+	code, err := js2Go(classJs, scopeKey, cacheExpr)
 	if err != nil {
 		panic(err)
 	}
@@ -20,7 +21,7 @@ func genPropsClassCode(classJs string) string {
 	return code
 }
 
-func genProps(props Props) string {
+func genProps(props Props, scopeKey string, cacheExpr bool) string {
 	if len(props) == 0 {
 		return "Props{}"
 	}
@@ -37,9 +38,9 @@ func genProps(props Props) string {
 	for _, p := range props {
 		k := p.Key
 		v := p.Val
-		valueCode, err := ast.Js2Go(v, ScopeKey)
+		valueCode, err := js2Go(v, scopeKey, cacheExpr)
 		if err != nil {
-			log.Panicf("%v, %s", err, v)
+			panic(fmt.Errorf("%v, %s", err, v))
 		}
 		dataCode += fmt.Sprintf(`"%s": %s,`, k, valueCode)
 	}
@@ -48,12 +49,22 @@ func genProps(props Props) string {
 	return fmt.Sprintf(`Props{orderKey: %s, data: %s}`, orderKeyCode, dataCode)
 }
 
-func genPropsStyleCode(styleJs string) string {
+// 生成v-bind="obj"的props代码, 明确绑定的props会覆盖obj中的同名字段
+func genBindProps(vBind string, props Props, scopeKey string, cacheExpr bool) string {
+	code, err := js2Go(vBind, scopeKey, cacheExpr)
+	if err != nil {
+		panic(err)
+	}
+
+	return fmt.Sprintf(`bindProps(r, %s, %s)`, code, genProps(props, scopeKey, cacheExpr))
+}
+
+func genPropsStyleCode(styleJs string, scopeKey string, cacheExpr bool) string {
 	if styleJs == "" {
 		return "nil"
 	}
 
-	code, err := ast.Js2Go(styleJs, ScopeKey)
+	code, err := js2Go(styleJs, scopeKey, cacheExpr)
 	if err != nil {
 		panic(err)
 	}
@@ -62,13 +73,17 @@ func genPropsStyleCode(styleJs string) string {
 }
 
 // 生成!动态节点的!attr, 包括class style和其他
-func genAllAttrCode(e *VueElement) string {
+// canonical: 是否按规范的顺序输出属性, 见Compiler.CanonicalAttrs
+// xhtml: 是否按XHTML的格式输出bool属性, 见Compiler.XHTML
+// extractStyles为true时style会被提取到样式表中, 见Compiler.ExtractStyles
+func genAllAttrCode(e *VueElement, scopeKey string, cacheExpr bool, canonical bool, xhtml bool, extractStyles bool) string {
 	var a = ""
 
 	// go代码
 	var classCode = ""
 	var styleCode = ""
 	var attrCode = ""
+	var staticClassCode, classPropsCode string
 
 	// 查找props中的class 与 style, 将处理为动态class
 	classProps, _ := e.Props.Get("class")
@@ -79,13 +94,13 @@ func genAllAttrCode(e *VueElement) string {
 	// class
 	{
 		// 静态Class GoCode
-		staticClassCode := sliceStringToGoCode(e.Class)
+		staticClassCode = sliceStringToGoCode(e.Class)
 
 		// 动态class GoCode
-		classPropsCode := "nil"
+		classPropsCode = "nil"
 		if classProps != "" {
 			var err error
-			classPropsCode, err = ast.Js2Go(classProps, ScopeKey)
+			classPropsCode, err = js2Go(classProps, scopeKey, cacheExpr)
 			if err != nil {
 				panic(err)
 			}
@@ -106,12 +121,16 @@ func genAllAttrCode(e *VueElement) string {
 		stylePropsCode := "nil"
 		if styleProps != "" {
 			var err error
-			stylePropsCode, err = ast.Js2Go(styleProps, ScopeKey)
+			stylePropsCode, err = js2Go(styleProps, scopeKey, cacheExpr)
 			if err != nil {
 				panic(err)
 			}
 		}
-		if stylePropsCode != "nil" {
+		if extractStyles && (staticStyleCode != "nil" || stylePropsCode != "nil") {
+			// class与style一起在运行时生成
+			classCode = fmt.Sprintf(`r.extractStyle(nil, %s, %s, %s, %s)`, staticClassCode, classPropsCode, staticStyleCode, stylePropsCode)
+			styleCode = ``
+		} else if stylePropsCode != "nil" {
 			// todo 可以预先判断static与Props是否有key冲突, 如果key不冲突, 则可以直接把static生成为go代码
 			styleCode = fmt.Sprintf(`mixinStyle(nil, %s, %s)`, staticStyleCode, stylePropsCode)
 		} else if staticStyleCode == "nil" {
@@ -129,13 +148,25 @@ func genAllAttrCode(e *VueElement) string {
 
 		// todo 可以预先判断static与Props是否有key冲突, 如果key不冲突, 则可以直接把static生成为go代码
 		if len(attrProps) != 0 {
-			attrPropsCode := genProps(attrProps)
-			attrCode = fmt.Sprintf(`mixinAttr(nil, %s, %s)`, staticAttrCode, attrPropsCode)
+			attrPropsCode := genProps(attrProps, scopeKey, cacheExpr)
+			if xhtml {
+				attrCode = fmt.Sprintf(`mixinAttrXHTML(nil, %s, %s, %t)`, staticAttrCode, attrPropsCode, canonical)
+			} else {
+				mixin := "mixinAttr"
+				if canonical {
+					mixin = "mixinAttrCanonical"
+				}
+				attrCode = fmt.Sprintf(`%s(nil, %s, %s)`, mixin, staticAttrCode, attrPropsCode)
+			}
 		} else if staticAttrCode == "nil" {
 			attrCode = ``
 		} else {
 			// 静态attrs 字符串
-			attrCode = safeStringCode(fmt.Sprintf(` %s`, genAttr(e.Attrs)))
+			attrs := e.Attrs
+			if xhtml {
+				attrs = xhtmlAttrs(attrs)
+			}
+			attrCode = safeStringCode(fmt.Sprintf(` %s`, genAttr(attrs)))
 		}
 	}
 
@@ -166,18 +197,125 @@ func genAllAttrCode(e *VueElement) string {
 	return a
 }
 
+// 返回属性按规范的顺序排序后的节点副本: id在最前, 其他按名字排序, 与运行时的canonicalAttrLess一致. 不修改原节点
+func sortAttrs(e *VueElement) *VueElement {
+	n := *e
+	n.Attrs = append([]Attribute(nil), e.Attrs...)
+	n.Props = append(Props(nil), e.Props...)
+	less := func(a, b string) bool {
+		if a == "id" || b == "id" {
+			return a == "id" && b != "id"
+		}
+		return a < b
+	}
+	sort.SliceStable(n.Attrs, func(i, j int) bool {
+		return less(n.Attrs[i].Key, n.Attrs[j].Key)
+	})
+	sort.SliceStable(n.Props, func(i, j int) bool {
+		return less(n.Props[i].Key, n.Props[j].Key)
+	})
+	return &n
+}
+
+// bool属性, 与运行时使用同一个表
+var boolAttrs = builtinSet("boolAttr")
+
+// 将没有值的bool属性(disabled)转为XHTML的格式(disabled="disabled"), 不修改原来的attrs
+func xhtmlAttrs(attrs []Attribute) []Attribute {
+	r := make([]Attribute, len(attrs))
+	for i, a := range attrs {
+		if a.Val == "" && boolAttrs[a.Key] {
+			a.Val = a.Key
+		}
+		r[i] = a
+	}
+	return r
+}
+
+// 取出<html>上静态的lang与dir属性, 见Render.Locale
+func takeLocaleAttrs(attrs []Attribute) (rest []Attribute, locale []Attribute) {
+	for _, a := range attrs {
+		if a.Key == "lang" || a.Key == "dir" {
+			locale = append(locale, a)
+		} else {
+			rest = append(rest, a)
+		}
+	}
+	return
+}
+
+// 生成<html>节点静态attr的代码, lang/dir在原来的位置由运行时的localeAttr根据Render.Locale生成, 模板中的值(编译时转义)作为默认值.
+// 模板中没有的lang/dir追加在最后
+func genLocaleAttrsCode(attrs []Attribute, xhtml bool) string {
+	if xhtml {
+		attrs = xhtmlAttrs(attrs)
+	}
+	var codes []string
+	var static []Attribute
+	flush := func() {
+		if len(static) != 0 {
+			codes = append(codes, safeStringCode(fmt.Sprintf(` %s`, genAttr(static))))
+			static = nil
+		}
+	}
+	found := map[string]bool{}
+	for _, a := range attrs {
+		if a.Key != "lang" && a.Key != "dir" {
+			static = append(static, a)
+			continue
+		}
+		flush()
+		codes = append(codes, fmt.Sprintf(`localeAttr(r, %q, %s)`, a.Key, strconv.Quote(html.EscapeString(a.Val))))
+		found[a.Key] = true
+	}
+	flush()
+	for _, k := range []string{"lang", "dir"} {
+		if !found[k] {
+			codes = append(codes, fmt.Sprintf(`localeAttr(r, %q, "")`, k))
+		}
+	}
+	return strings.Join(codes, "+")
+}
+
+// 组件上没有值的属性(如<toggle active>)和vue一样作为值为true的prop传给组件, 而不是值为空字符串的attr.
+// 写明了空值的属性(如<toggle active="">)仍然是attr
+func componentAttrs(e *VueElement) (attrs []Attribute, props Props) {
+	// 限制容量, append时不会修改e.Props
+	props = e.Props[:len(e.Props):len(e.Props)]
+	for _, a := range e.Attrs {
+		if a.NoValue {
+			props = append(props, Prop{Key: a.Key, Val: "true"})
+		} else {
+			attrs = append(attrs, a)
+		}
+	}
+	return
+}
+
 func genAttrsCode(a []Attribute) string {
 	if len(a) == 0 {
 		return "nil"
 	}
 	st := "[]Attribute{\n"
 	for _, v := range a {
-		st += fmt.Sprintf(`{Key: %s, Val: %s},`, safeStringCode(v.Key), safeStringCode(v.Val))
+		st += fmt.Sprintf(`{Key: %s, Val: %s},`, safeStringCode(v.Key), safeStringCode(html.EscapeString(v.Val)))
 	}
 	st += "\n}"
 	return st
 }
 
+// 生成静态attrs与:foo.attr的代码, :foo.attr在运行时计算后追加到静态attrs之后
+func genAttrsWithPropsCode(a []Attribute, attrProps Props, scopeKey string, cacheExpr bool) string {
+	if len(attrProps) == 0 {
+		return genAttrsCode(a)
+	}
+	propsCode := fmt.Sprintf("getAttrFromProps(%s)", genProps(attrProps, scopeKey, cacheExpr))
+	if len(a) == 0 {
+		return propsCode
+	}
+	return fmt.Sprintf("append(%s, %s...)", genAttrsCode(a), propsCode)
+}
+
 // 生成静态style
 func genStyle(style map[string]string, styleKeys []string) string {
 	st := ""
@@ -200,7 +338,7 @@ func genAttr(attr []Attribute) string {
 			c.WriteString(" ")
 		}
 		if v != "" {
-			c.WriteString(fmt.Sprintf(`%s="%s"`, k, v))
+			c.WriteString(fmt.Sprintf(`%s="%s"`, k, html.EscapeString(v)))
 		} else {
 			c.WriteString(fmt.Sprintf(`%s`, k))
 		}
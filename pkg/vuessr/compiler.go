@@ -5,8 +5,15 @@ import (
 	"github.com/zbysir/go-vue-ssr/internal/pkg/log"
 	"github.com/zbysir/go-vue-ssr/pkg/vuessr/ast"
 	"github.com/zbysir/go-vue-ssr/pkg/vuessr/parser"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"html"
+	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +22,91 @@ type Compiler struct {
 	// 如果在编译期间遇到的tag在components中, 就会使用组件方法.
 	// key是tag名字, value是驼峰
 	Components map[string]string
+	// 使用Go代码实现的组件, 格式同Components, 见AddCodeComponent
+	CodeComponents map[string]string
+	// Components与CodeComponents的大小写无关索引, 见lookupComponent
+	componentIndex     map[string]string
+	codeComponentIndex map[string]string
+	// 已解析的组件文件(由LoadDir加载), key是驼峰组件名
+	Files map[string]*VueFile
+	// 生成代码中作用域变量的名字, 默认为ScopeKey
+	// 当模板中的变量或者方法名与它冲突时可以修改, 需要是合法的名字, 见CheckScopeKey
+	ScopeKey string
+	// 是否在生成的代码中添加注释(// 文件:行号)来标明代码来自模板的哪一行, 用于调试
+	SourceMap bool
+	// 当前正在编译的文件, 用于SourceMap
+	file string
+	// GenAllFile的src目录, v-memo缓存的key使用相对于它的路径, 见memoFile
+	src string
+	// 当前文件中v-memo的数量, 用于生成缓存的key
+	memoCount int
+	// 没有子元素的节点, 会渲染成<br/>这样的格式, 默认为html的void元素
+	// 渲染xml等非html格式时可以修改, 运行时(动态节点)会使用同样的设置
+	VoidElements map[string]bool
+	// 块级元素, 用于Whitespace为WhitespaceReadable时的换行与TrimInterpolation, 不在其中的元素作为行内元素处理, 默认为html的块级元素
+	BlockElements map[string]bool
+	// 内容不是html的元素(如script/style中的js/css), 其中的文本不会编码实体, 也不受EmptyBool的影响, 默认为script与style
+	// 注意: 解析模板时只有html的script/style等元素的内容会作为纯文本解析
+	RawTextElements map[string]bool
+	// 是否校验v-for节点上的:key表达式
+	// 开启后会编译(但不输出):key表达式, 并检查它是否引用了v-for的变量, 用于尽早发现如:key="itm.id"的拼写错误
+	ValidateKey bool
+	// 只在客户端生效的指令(如v-focus), key是指令名(包含v-前缀)
+	// 服务端渲染时会跳过这些指令, 不会生成任何运行时代码.
+	ClientDirectives map[string]bool
+	// 是否输出客户端激活所需的信息, 开启后ClientDirectives中的指令会原样输出为attr(如v-focus="true"), 供客户端使用
+	// v-on也会输出为attr, 事件的修饰符会被保留(如v-on:click.prevent="submit(id)"), 没有开启时v-on会被丢弃
+	Hydrate bool
+	// 表达式中允许调用的方法名, 为nil时不限制
+	// 用于编译不受信任的模板, 调用了不在其中的方法会在编译期报错. 过滤器(| filter)由RenderCreator注册, 不受此限制.
+	AllowedFuncs map[string]bool
+	// 表达式中允许读取的变量名(如props与RenderCreator.Var注册的变量), 为nil时不限制. 和AllowedFuncs一起使用.
+	// v-for/v-let/v-slot声明的变量在节点(包括子节点)中总是可以读取
+	AllowedVars map[string]bool
+	// 正在编译的节点与其祖先节点声明的变量, 见AllowedVars
+	localVars []string
+	// 正在编译的节点是否在<svg>中, svg中的<title>不是页面的标题
+	inSVG bool
+	// 编译期的开关, 用于v-build-if="amp"与v-build-if="!amp"
+	// 和v-if不同, 条件不满足的节点(包括其v-if/v-else分支)在编译期就会被去掉, 不会生成任何代码
+	BuildFlags map[string]bool
+	// 是否清理html节点上:href/:src/:action绑定的url, 开启后不安全的协议(如javascript:)会被替换, 空白与非ASCII字符等会被百分号编码
+	// 默认关闭, 运行时(动态节点)会使用同样的设置, 清理v-bind="obj"中的字段与组件根节点继承的属性(如组件上的:href.attr)
+	SanitizeURL bool
+	// 是否按规范的顺序输出属性: class, style, id, 其他属性按名字排序. 使书写顺序不同但属性相同的节点输出相同的html, 便于缓存与去重
+	// 默认按书写的顺序输出, 运行时(动态节点)会使用同样的设置
+	CanonicalAttrs bool
+	// 节点上有重复的属性(如id="a" id="b")时是否报错, 默认只输出警告并使用第一个属性, 见VueElementParser.StrictAttrs
+	StrictAttrs bool
+	// 内联节点数不超过InlineSize的组件: 在使用组件的地方直接生成组件的代码, 而不是调用组件方法, 以生成更多的代码为代价减少渲染时的开销.
+	// 递归(直接或间接使用自身)的组件不会被内联. 需要组件已经被解析(见LoadDir), 默认为0: 不内联
+	InlineSize int
+	// 读取模板的来源, 为nil时从文件系统读取, 见TemplateSource
+	Source TemplateSource
+	// 节点之间空白的处理方式, 默认WhitespaceRemove
+	Whitespace WhitespaceMode
+	// 模板文件的扩展名, LoadDir/GenAllFile只会加载这个扩展名的文件, 文件名去掉扩展名后作为组件名. 默认为.vue
+	Ext string
+	// 是否在生成的html中保留模板中的注释, 注释会原样输出, 其中的{{}}不会被计算. 默认去掉注释
+	KeepComments bool
+	// 是否在一次渲染中缓存多级路径表达式(如user.profile.avatar)的值, 同一个表达式在v-for中被多次使用时可以减少查找的开销.
+	// 只缓存v-for/插槽作用域之外的变量, 见运行时的cachedGet. 开启后渲染过程中不应修改传入的数据. 默认不缓存
+	CacheExpr bool
+	// 是否按XHTML的格式输出: bool属性(如disabled)输出为disabled="disabled", 默认按html的格式输出为disabled
+	XHTML bool
+	// 表达式语法树的最大深度与最大节点数, 为0时不限制
+	// 用于编译不受信任的模板, 超过限制的表达式会在编译期报错, 和AllowedFuncs一起使用
+	MaxExprDepth int
+	MaxExprNodes int
+	// 插值中的bool值是否输出为空字符串, 用于{{ isActive }}这样作为标记使用的插值
+	// 默认和vue一样输出为true/false, v-text, 属性与script/style中的插值不受影响
+	EmptyBool bool
+	// 块级元素(如<p>/<li>)中只有一个插值时, 去掉插值前后的空白, 如<p>  {{ x }}  </p>会输出为<p>x</p>
+	// 行内元素(如<span>)中的空白不受影响. 默认不去掉
+	TrimInterpolation bool
+	// 是否将节点上的style提取到渲染时收集的样式表中, 用生成的class代替style属性, 用于不允许内联样式(unsafe-inline)的CSP
+	// 样式表使用r.Styles()或RenderResult.Styles读取, 运行时(动态节点)会使用同样的设置. 默认不提取
+	ExtractStyles bool
 }
 
 type Prop struct {
@@ -45,12 +137,16 @@ func (p *Props) Del(key string) {
 type OptionsGen struct {
 	Props           Props             // 上级传递的 数据(包含了class和style)
 	Attrs           []Attribute       // 上级传递的 静态的attrs (除去class和style), 只会作用在root节点
+	AttrProps       Props             // :foo.attr, 和Attrs一样作用在root节点
 	Class           []string          // 静态class
 	Style           map[string]string // 静态style
 	Slot            map[string]string // 插槽节点
 	DefaultSlotCode string            // 子节点code, 用于默认的插槽
 	NamedSlotCode   map[string]string // 具名插槽
 	Directives      []Directive       // 指令代码
+	VBind           string            // v-bind="obj", 将obj中的字段全部当作props
+	ScopeKey        string            // 生成代码中作用域变量的名字
+	CacheExpr       bool              // 见Compiler.CacheExpr
 }
 
 func sliceStringToGoCode(m []string) string {
@@ -125,7 +221,7 @@ func sliceToGoCode(m []string) string {
 }
 
 // 根据js代码生成go代码(基于js AST)
-func mapJsCodeToCode(m map[string]string) string {
+func mapJsCodeToCode(m map[string]string, scopeKey string) string {
 	if len(m) == 0 {
 		return "nil"
 	}
@@ -133,9 +229,9 @@ func mapJsCodeToCode(m map[string]string) string {
 	props += "{"
 	for _, k := range getSortedKey(m) {
 		v := m[k]
-		valueCode, err := ast.Js2Go(v, ScopeKey)
+		valueCode, err := ast.Js2Go(v, scopeKey)
 		if err != nil {
-			log.Panicf("%v, %s", err, v)
+			panic(fmt.Errorf("%v, %s", err, v))
 		}
 		props += fmt.Sprintf(`"%s": %s,`, k, valueCode)
 	}
@@ -144,6 +240,26 @@ func mapJsCodeToCode(m map[string]string) string {
 	return props
 }
 
+// 生成Slots代码, 没有插槽时(如叶子组件)不生成, 省去每次调用时创建map与闭包的开销
+func (o *OptionsGen) genSlotsCode() string {
+	slot := map[string]string{}
+
+	children := o.DefaultSlotCode
+	if t := strings.TrimSpace(children); t != `""` && t != "" {
+		slot["default"] = fmt.Sprintf(`func(w Writer, props Props){
+%s
+}`, children)
+	}
+
+	for k, v := range o.NamedSlotCode {
+		slot[k] = v
+	}
+	if len(slot) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Slots: %s,\n", mapGoCodeToCode(slot, "NamedSlotFunc", false))
+}
+
 // 生成Options代码
 func (o *OptionsGen) ToGoCode() string {
 	c := "&Options{\n"
@@ -153,25 +269,28 @@ func (o *OptionsGen) ToGoCode() string {
 		classJs, ok := o.Props.Get("class")
 		if ok {
 			o.Props.Del("class")
-			cCode := genPropsClassCode(classJs)
+			cCode := genPropsClassCode(classJs, o.ScopeKey, o.CacheExpr)
 			c += fmt.Sprintf("PropsClass: %s, \n", cCode)
 		}
 		styleJs, ok := o.Props.Get("style")
 		// style
 		if ok {
 			o.Props.Del("style")
-			cStyle := genPropsStyleCode(styleJs)
+			cStyle := genPropsStyleCode(styleJs, o.ScopeKey, o.CacheExpr)
 			c += fmt.Sprintf("PropsStyle: %s, \n", cStyle)
 		}
 
 		// 除了class/style的props
-		if len(o.Props) != 0 {
-			c += fmt.Sprintf("Props: %s, \n", genProps(o.Props))
+		if len(o.Props) != 0 && o.VBind == "" {
+			c += fmt.Sprintf("Props: %s, \n", genProps(o.Props, o.ScopeKey, o.CacheExpr))
 		}
 	}
+	if o.VBind != "" {
+		c += fmt.Sprintf("Props: %s, \n", genBindProps(o.VBind, o.Props, o.ScopeKey, o.CacheExpr))
+	}
 
-	if len(o.Attrs) != 0 {
-		c += fmt.Sprintf("Attrs: %s,\n", genAttrsCode(o.Attrs))
+	if len(o.Attrs) != 0 || len(o.AttrProps) != 0 {
+		c += fmt.Sprintf("Attrs: %s,\n", genAttrsWithPropsCode(o.Attrs, o.AttrProps, o.ScopeKey, o.CacheExpr))
 	}
 	if len(o.Class) != 0 {
 		c += fmt.Sprintf("Class: %s,\n", sliceToGoCode(o.Class))
@@ -181,19 +300,7 @@ func (o *OptionsGen) ToGoCode() string {
 	}
 
 	// slot
-	slot := map[string]string{}
-
-	children := o.DefaultSlotCode
-	if children != `""` {
-		slot["default"] = fmt.Sprintf(`func(w Writer, props Props){
-%s
-}`, children)
-	}
-
-	for k, v := range o.NamedSlotCode {
-		slot[k] = v
-	}
-	c += fmt.Sprintf("Slots: %s,\n", mapGoCodeToCode(slot, "NamedSlotFunc", false))
+	c += o.genSlotsCode()
 
 	// p 父级option
 	c += fmt.Sprintf("P: options,\n")
@@ -206,7 +313,7 @@ func (o *OptionsGen) ToGoCode() string {
 			valueCode := "nil"
 			if v.Value != "" {
 				var err error
-				valueCode, err = ast.Js2Go(v.Value, ScopeKey)
+				valueCode, err = js2Go(v.Value, o.ScopeKey, o.CacheExpr)
 				if err != nil {
 					panic(err)
 				}
@@ -219,7 +326,7 @@ func (o *OptionsGen) ToGoCode() string {
 	}
 
 	// Scope
-	c += fmt.Sprintf("Scope: %s,\n", ScopeKey)
+	c += fmt.Sprintf("Scope: %s,\n", o.ScopeKey)
 
 	c += "}"
 	return c
@@ -242,25 +349,28 @@ func (o *OptionsGen) ToGoCodeForRoot() string {
 		classJs, ok := o.Props.Get("class")
 		if ok {
 			o.Props.Del("class")
-			cCode := genPropsClassCode(classJs)
+			cCode := genPropsClassCode(classJs, o.ScopeKey, o.CacheExpr)
 			c += fmt.Sprintf("PropsClass: %s, \n", cCode)
 		}
 		styleJs, ok := o.Props.Get("style")
 		// style
 		if ok {
 			o.Props.Del("style")
-			cStyle := genPropsStyleCode(styleJs)
+			cStyle := genPropsStyleCode(styleJs, o.ScopeKey, o.CacheExpr)
 			c += fmt.Sprintf("PropsStyle: %s, \n", cStyle)
 		}
 
 		// 除了class/style的props
-		if len(o.Props) != 0 {
-			c += fmt.Sprintf("Props: %s, \n", genProps(o.Props))
+		if len(o.Props) != 0 && o.VBind == "" {
+			c += fmt.Sprintf("Props: %s, \n", genProps(o.Props, o.ScopeKey, o.CacheExpr))
 		}
 	}
+	if o.VBind != "" {
+		c += fmt.Sprintf("Props: %s, \n", genBindProps(o.VBind, o.Props, o.ScopeKey, o.CacheExpr))
+	}
 
-	if len(o.Attrs) != 0 {
-		c += fmt.Sprintf("Attrs: %s,\n", genAttrsCode(o.Attrs))
+	if len(o.Attrs) != 0 || len(o.AttrProps) != 0 {
+		c += fmt.Sprintf("Attrs: %s,\n", genAttrsWithPropsCode(o.Attrs, o.AttrProps, o.ScopeKey, o.CacheExpr))
 	}
 	if len(o.Class) != 0 {
 		c += fmt.Sprintf("Class: %s,\n", sliceToGoCode(o.Class))
@@ -270,19 +380,7 @@ func (o *OptionsGen) ToGoCodeForRoot() string {
 	}
 
 	// slot
-	slot := map[string]string{}
-
-	children := o.DefaultSlotCode
-	if children != `""` {
-		slot["default"] = fmt.Sprintf(`func(w Writer, props Props){
-%s
-}`, children)
-	}
-
-	for k, v := range o.NamedSlotCode {
-		slot[k] = v
-	}
-	c += fmt.Sprintf("Slots: %s,\n", mapGoCodeToCode(slot, "NamedSlotFunc", false))
+	c += o.genSlotsCode()
 
 	// p 父级option
 	c += fmt.Sprintf("P: options,\n")
@@ -290,25 +388,31 @@ func (o *OptionsGen) ToGoCodeForRoot() string {
 	// directive
 	{
 		// 数组
-		dir := "append(options.Directives,\n"
+		// 没有指令时不生成append(options.Directives,), 生成的代码需要通过go vet
+		dir := "options.Directives"
+		if len(o.Directives) != 0 {
+			dir = "append(options.Directives,\n"
+		}
 		for _, v := range o.Directives {
 			valueCode := "nil"
 			if v.Value != "" {
 				var err error
-				valueCode, err = ast.Js2Go(v.Value, ScopeKey)
+				valueCode, err = js2Go(v.Value, o.ScopeKey, o.CacheExpr)
 				if err != nil {
 					panic(err)
 				}
 			}
 			dir += fmt.Sprintf("directive{Name: \"%s\", Value: %s, Arg: \"%s\"},\n", v.Name, valueCode, v.Arg)
 		}
-		dir += ")"
+		if len(o.Directives) != 0 {
+			dir += ")"
+		}
 
 		c += fmt.Sprintf("Directives: %s,\n", dir)
 	}
 
 	// Scope
-	c += fmt.Sprintf("Scope: %s,\n", ScopeKey)
+	c += fmt.Sprintf("Scope: %s,\n", o.ScopeKey)
 
 	c += "}"
 	return c
@@ -343,6 +447,75 @@ var voidElements = map[string]bool{
 	"wbr":    true,
 }
 
+// 节点之间空白的处理方式
+type WhitespaceMode int
+
+const (
+	// 去掉节点之间只有空白的文本, 文本中的空白原样输出
+	WhitespaceRemove WhitespaceMode = iota
+	// 在块级元素前后输出一个换行, 去掉文本中换行后的缩进, 便于调试时阅读html
+	WhitespaceReadable
+)
+
+// 默认的块级元素, 见Compiler.BlockElements
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true,
+	"dd": true, "details": true, "dialog": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"head": true, "header": true, "hr": true, "html": true, "li": true, "link": true,
+	"main": true, "meta": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"script": true, "section": true, "style": true, "table": true, "tbody": true,
+	"td": true, "tfoot": true, "th": true, "thead": true, "title": true, "tr": true, "ul": true,
+}
+
+var readableSpace = regexp.MustCompile(`[ \t]*\n\s*`)
+
+// WhitespaceReadable下块级元素中的文本: 去掉换行后的缩进;
+// 开头(first)与结尾(last)处含换行的空白是模板的排版, 直接去掉, 不在行内内容中加入换行, 如<li>a</li>
+func readableText(text string, first, last bool) string {
+	if first {
+		if t := strings.TrimLeft(text, " \t\r\n"); strings.Contains(text[:len(text)-len(t)], "\n") {
+			text = t
+		}
+	}
+	if last {
+		if t := strings.TrimRight(text, " \t\r\n"); strings.Contains(text[len(t):], "\n") {
+			text = t
+		}
+	}
+	return readableSpace.ReplaceAllString(text, "\n")
+}
+
+// 是否是WhitespaceReadable下需要换行的html节点, 组件与template不是
+func (c *Compiler) isBlock(e *VueElement) bool {
+	if c.Whitespace != WhitespaceReadable || e.NodeType != parser.ElementNode || !c.BlockElements[e.TagName] {
+		return false
+	}
+	return !c.isComponent(e)
+}
+
+func (c *Compiler) isComponent(e *VueElement) bool {
+	if e.NodeType != parser.ElementNode {
+		return false
+	}
+	_, ok := c.component(e.TagName)
+	return ok
+}
+
+// 默认的rawTextElements, 其中的文本不是html, 如js/css代码, 见Compiler.RawTextElements
+var rawTextElements = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// 浏览器会忽略这些节点内容开头的一个换行, 所以内容以换行开头时需要多输出一个换行, 如<textarea>{{ value }}</textarea>
+var leadingNewlineElements = map[string]bool{
+	"textarea": true,
+	"pre":      true,
+	"listing":  true,
+}
+
 // 组件渲染,
 // 如果该组件被components注册, 则使用Element渲染.
 //
@@ -354,77 +527,139 @@ var voidElements = map[string]bool{
 // 每个组件都是一个func或者是一个字符串
 // slot: 子级代码
 // 返回的code 是一行代码,
+// 模板错误会panic(*ParseError), 行号为出错的节点所在行
 func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[string]string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panic(toParseError(r, e.Line))
+		}
+	}()
+	if e.BuildIf != "" && !c.buildIf(e.BuildIf) {
+		return "", nil
+	}
+	// v-if="false"且没有else分支时, 不需要生成节点与子节点的代码
+	if e.VIf != nil && len(e.VIf.ElseIf) == 0 {
+		if v, ok := constCondition(e.VIf.Condition); ok && !v {
+			return "", nil
+		}
+	}
+	if c.AllowedVars != nil {
+		n := len(c.localVars)
+		c.localVars = append(c.localVars, declaredVars(e)...)
+		defer func() { c.localVars = c.localVars[:n] }()
+	}
+	c.checkExprs(e)
+	if e.TagName == "svg" || e.TagName == "foreignObject" {
+		// foreignObject中又是html节点
+		inSVG := c.inSVG
+		c.inSVG = e.TagName == "svg"
+		defer func() { c.inSVG = inSVG }()
+	}
+
 	var eleCode = ""
 
 	defaultSlotCode := ""
 
 	namedSlotCode = map[string]string{}
+	// 是否有v-slot子节点
+	hasSlotChild := false
 	if len(e.Children) != 0 {
-		for _, v := range e.Children {
+		for i, v := range e.Children {
 			// 跳过生成else节点的代码, 真正生成else节点的代码在if节点中
 			if v.VElse || v.VElseIf {
 				continue
 			}
-			childCode, childNamedSlotCode := c.GenEleCode(v)
-			for k, v := range childNamedSlotCode {
-				namedSlotCode[k] = v
+			var childCode string
+			var childNamedSlotCode map[string]string
+			if v.NodeType == parser.TextNode && !c.RawTextElements[e.TagName] && !leadingNewlineElements[e.TagName] {
+				text := v.Text
+				if c.isBlock(e) {
+					text = readableText(text, i == 0, i == len(e.Children)-1)
+				}
+				if c.TrimInterpolation && len(e.Children) == 1 && c.BlockElements[e.TagName] && isSoleInterpolation(text) {
+					text = strings.TrimSpace(text)
+				}
+				// 不修改AST, 同一个节点可能被多次编译
+				if text != v.Text {
+					cp := *v
+					cp.Text = text
+					v = &cp
+				}
+			}
+			if v.NodeType == parser.TextNode && c.RawTextElements[e.TagName] {
+				// script/style中的文本不经过GenEleCode, 需要单独检查其中的插值
+				c.checkExprs(v)
+				childCode = c.genTextCode(v.Text, true)
+			} else {
+				childCode, childNamedSlotCode = c.GenEleCode(v)
 			}
+			mergeNamedSlotCode(namedSlotCode, childNamedSlotCode)
 
 			if childCode == "" {
 				continue
 			}
+			// v-slot节点的代码已经放在了namedSlotCode里, 自身只是一个空字符串占位, 不应该出现在默认插槽中
+			if childCode == `""` {
+				hasSlotChild = true
+				continue
+			}
 			defaultSlotCode += childCode + "\n"
 		}
 	}
+	// 最后一个子节点是块级元素或组件(组件的根节点一般是块级元素)时, 在结束标签前换行
+	if n := len(e.Children); n != 0 && c.isBlock(e) && (c.isBlock(e.Children[n-1]) || c.isComponent(e.Children[n-1])) {
+		defaultSlotCode += `w.WriteString("\n")` + "\n"
+	}
 	defaultSlotCode = strings.TrimSuffix(defaultSlotCode, "\n")
+	// 只有v-slot子节点时没有默认插槽
+	if defaultSlotCode == "" && hasSlotChild {
+		defaultSlotCode = `""`
+	}
 
 	switch e.NodeType {
 	case parser.TextNode:
 		// 纯字符串节点
-		// 将文本处理成go代码的字符串写法: "xxx"
-		// 注意{{表达式中的"不应该被处理, 因为这是js代码, 需要解析成为JS AST.
-		text := safeStringCode(e.Text)
-		// 处理变量
-		text = injectVal(text)
-		eleCode = fmt.Sprintf(`w.WriteString(%s)`, text)
+		eleCode = c.genTextCode(e.Text, false)
 	case parser.DocumentNode:
 		log.Infof("DocumentNode %+v", e)
 	case parser.ElementNode:
+		e = c.stripClientDirectives(e)
+		e = c.hydrateEvents(e)
+
 		// 判断是否是自定义组件
-		componentName, exist := c.Components[e.TagName]
+		componentName, exist := c.component(e.TagName)
 		if exist {
-			options := OptionsGen{
-				Class:           e.Class,
-				Attrs:           e.Attrs,
-				Props:           e.Props,
-				Style:           e.Style,
-				DefaultSlotCode: defaultSlotCode,
-				NamedSlotCode:   namedSlotCode,
-				Directives:      e.Directives,
-			}
-			optionsCode := options.ToGoCode()
-			eleCode = fmt.Sprintf("xx_%s(r, w, %s)", componentName, optionsCode)
-		} else if e.TagName == "component" || e.TagName == "slot" || e.TagName == "async" {
+			optionsCode := c.genComponentOptionsCode(e, defaultSlotCode, namedSlotCode)
+			if code, ok := c.inlineComponent(componentName, optionsCode); ok {
+				eleCode = code
+			} else {
+				eleCode = fmt.Sprintf("xx_%s(r, w, %s)", componentName, optionsCode)
+			}
+			// 子节点中的具名插槽属于这个组件, 不再传递给上级组件
+			namedSlotCode = map[string]string{}
+		} else if codeName, ok := c.codeComponent(e.TagName); ok {
+			// Go代码实现的组件, 在运行时查找
+			optionsCode := c.genComponentOptionsCode(e, defaultSlotCode, namedSlotCode)
+			eleCode = fmt.Sprintf("r.Render(\"%s\", w, %s)", codeName, optionsCode)
+			namedSlotCode = map[string]string{}
+		} else if e.TagName == "component" || e.TagName == "slot" || e.TagName == "async" || e.TagName == "teleport" {
 			// 自带组件
-			options := OptionsGen{
-				Class:           e.Class,
-				Attrs:           e.Attrs,
-				Props:           e.Props,
-				Style:           e.Style,
-				DefaultSlotCode: defaultSlotCode,
-				NamedSlotCode:   namedSlotCode,
-				Directives:      e.Directives,
-			}
-			optionsCode := options.ToGoCode()
+			var optionsCode string
+			if e.TagName == "component" {
+				// 动态组件和其他组件一样处理属性
+				optionsCode = c.genComponentOptionsCode(e, defaultSlotCode, namedSlotCode)
+				namedSlotCode = map[string]string{}
+			} else {
+				optionsCode = c.genOptionsCode(e, e.Attrs, e.Props, defaultSlotCode, namedSlotCode)
+			}
 			eleCode = fmt.Sprintf("_%s(r, w, %s)", e.TagName, optionsCode)
 		} else if e.TagName == "template" {
 			// template和其他自带组件不一样: 它可以包含额外多个功能: 使用v-html/v-text
 			children := defaultSlotCode
 			if e.VHtml != "" {
-				children = genVHtml(e.VHtml)
+				children = genVHtml(e.VHtml, c.ScopeKey, c.CacheExpr)
 			} else if e.VText != "" {
-				children = genVText(e.VText)
+				children = genVText(e.VText, c.ScopeKey, c.CacheExpr)
 			}
 
 			// 如果没有指令, 则直接输出子级
@@ -439,6 +674,9 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 					DefaultSlotCode: children,
 					NamedSlotCode:   namedSlotCode,
 					Directives:      e.Directives,
+					VBind:           e.VBind,
+					ScopeKey:        c.ScopeKey,
+					CacheExpr:       c.CacheExpr,
 				}
 				optionsCode := options.ToGoCode()
 				eleCode = fmt.Sprintf("_%s(r, w, %s)", e.TagName, optionsCode)
@@ -446,6 +684,16 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 
 		} else {
 			// 基础html标签
+			// 作用在基础标签上的props都会被渲染为attr, 所以:foo.attr和:foo一样处理
+			// 不修改AST, 同一个节点可能被多次编译
+			if len(e.AttrProps) != 0 {
+				n := *e
+				n.Props = append(e.Props[:len(e.Props):len(e.Props)], e.AttrProps...)
+				e = &n
+			}
+			if c.SanitizeURL {
+				e = sanitizeURLProps(e)
+			}
 
 			// 判断节点是否是动态节点, 动态则使用r.Tag渲染节点, 否则使用字符串拼接
 			// 动态节点
@@ -453,12 +701,13 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 			// - 组件的root节点: root节点会继承上层传递的(class/style/attr)
 
 			// 动态节点
-			if e.IsRoot || len(e.Directives) != 0 {
+			// - v-bind="obj": 在编译期无法知道obj中有哪些attr
+			if e.IsRoot || len(e.Directives) != 0 || e.VBind != "" {
 				children := defaultSlotCode
 				if e.VHtml != "" {
-					children = genVHtml(e.VHtml)
+					children = genVHtml(e.VHtml, c.ScopeKey, c.CacheExpr)
 				} else if e.VText != "" {
-					children = genVText(e.VText)
+					children = genVText(e.VText, c.ScopeKey, c.CacheExpr)
 				}
 
 				options := OptionsGen{
@@ -470,6 +719,15 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 					DefaultSlotCode: children,
 					NamedSlotCode:   namedSlotCode,
 					Directives:      e.Directives,
+					VBind:           e.VBind,
+					ScopeKey:        c.ScopeKey,
+					CacheExpr:       c.CacheExpr,
+				}
+
+				if e.TagName == "title" && !c.inSVG {
+					options.DefaultSlotCode = genTitle(children)
+				} else if leadingNewlineElements[e.TagName] {
+					options.DefaultSlotCode = genLeadingNewline(children)
 				}
 
 				if e.IsRoot {
@@ -482,18 +740,48 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 
 			} else {
 				// 静态节点
-				attrs := genAllAttrCode(e)
+				if c.CanonicalAttrs {
+					e = sortAttrs(e)
+				}
+				var attrs string
+				_, boundLang := e.Props.Get("lang")
+				_, boundDir := e.Props.Get("dir")
+				if e.TagName == "html" && !boundLang && !boundDir {
+					// 根据Render.Locale生成lang/dir属性, 模板中静态的lang/dir作为默认值
+					ec := *e
+					var locale []Attribute
+					if len(e.Props.Omit("class", "style")) == 0 {
+						// 只有静态attr时lang/dir在原来的位置输出
+						ec.Attrs, locale = nil, e.Attrs
+					} else {
+						// 静态attr与动态attr在运行时合并, lang/dir追加在最后
+						ec.Attrs, locale = takeLocaleAttrs(e.Attrs)
+					}
+					attrs = genAllAttrCode(&ec, c.ScopeKey, c.CacheExpr, c.CanonicalAttrs, c.XHTML, c.ExtractStyles)
+					attrs += "+" + genLocaleAttrsCode(locale, c.XHTML)
+				} else {
+					attrs = genAllAttrCode(e, c.ScopeKey, c.CacheExpr, c.CanonicalAttrs, c.XHTML, c.ExtractStyles)
+				}
+				if e.TagName == "script" || e.TagName == "style" {
+					// CSP nonce, 见Render.Nonce
+					attrs += "+nonceAttr(r)"
+				}
 				children := defaultSlotCode
 				if e.VHtml != "" {
-					children = genVHtml(e.VHtml)
+					children = genVHtml(e.VHtml, c.ScopeKey, c.CacheExpr)
 				} else if e.VText != "" {
-					children = genVText(e.VText)
+					children = genVText(e.VText, c.ScopeKey, c.CacheExpr)
+				}
+				if e.TagName == "title" && !c.inSVG && children != "" {
+					children = genTitle(children)
+				} else if leadingNewlineElements[e.TagName] && children != "" {
+					children = genLeadingNewline(children)
 				}
 
 				if children != "" {
 					eleCode = fmt.Sprintf("w.WriteString(\"<%s\"+%s+\">\")\n%s\nw.WriteString(\"</%s>\")", e.TagName, attrs, children, e.TagName)
 				} else {
-					if voidElements[e.TagName] {
+					if c.VoidElements[e.TagName] {
 						eleCode = fmt.Sprintf("w.WriteString(\"<%s\"+%s+\"/>\")", e.TagName, attrs)
 					} else {
 						eleCode = fmt.Sprintf("w.WriteString(\"<%s\"+%s+\"></%s>\")", e.TagName, attrs, e.TagName)
@@ -503,14 +791,34 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 		}
 
 	case parser.CommentNode:
+		// 只有KeepComments时才会有注释节点, 和vue一样注释中的{{}}不会被计算
+		eleCode = fmt.Sprintf("w.WriteString(%s)", strconv.Quote("<!--"+e.Text+"-->"))
 	case parser.DoctypeNode:
 		eleCode = fmt.Sprintf(`w.WriteString("<!doctype %s>")`, e.DocType)
 	default:
 		panic(fmt.Sprintf("bad nodeType, %+v", e))
 	}
 
-	// 优先级 vSlot > vFor > vIf, 所以先处理VIf(后处理的可覆盖前处理的)
+	// 块级元素前换行, 在v-for之内, 所以每次循环都会换行
+	if c.isBlock(e) {
+		eleCode = `w.WriteString("\n")` + "\n" + eleCode
+	}
+
+	// 优先级 vSlot > vFor > vLet > vIf, 所以先处理VIf(后处理的可覆盖前处理的)
 
+	// v-memo只缓存节点自身, 在v-if与v-for之内
+	if e.Memo != "" {
+		eleCode = genVMemo(fmt.Sprintf("%s:%d", c.memoFile(), c.memoCount), e.Memo, eleCode, c.ScopeKey, c.CacheExpr)
+		c.memoCount++
+	}
+	// v-head在v-memo之外, 使用缓存时也需要收集
+	if e.Head {
+		eleCode = genVHead(e, eleCode, c.ScopeKey, c.CacheExpr)
+	}
+	// v-join的分隔符在v-if之内, 没有渲染的循环不会输出分隔符
+	if e.VFor != nil && e.Join != "" {
+		eleCode = genVJoin(e.Join, eleCode)
+	}
 	if e.VIf != nil {
 		var namedSlotCodeElseIf map[string]string
 		eleCode, namedSlotCodeElseIf = genVIf(e.VIf, eleCode, c)
@@ -518,12 +826,27 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 			namedSlotCode[i] = v
 		}
 	}
+	// v-let在v-if之外, 所以v-if中可以使用v-let声明的变量; 在v-for之内, 所以v-let中可以使用v-for的变量.
+	if len(e.VLet) != 0 {
+		eleCode = genVLet(e.VLet, eleCode, c.ScopeKey, c.CacheExpr)
+	}
 	if e.VFor != nil {
-		eleCode = genVFor(e.VFor, eleCode)
+		if c.ValidateKey && e.Key != "" {
+			validateKey(e.VFor, e.Key, c.ScopeKey)
+		}
+		eleCode = genVFor(e.VFor, eleCode, c.ScopeKey, c.CacheExpr)
+		if e.Join != "" {
+			eleCode = fmt.Sprintf("{\nvJoined := false\n%s\n}", eleCode)
+		}
+	}
+	// 为主要的代码块(组件/动态节点/v-if/v-for)标明来源
+	// 如果代码已经被子节点标明了来源(如template只是直接输出子节点), 则不重复添加
+	if c.SourceMap && e.Line != 0 && eleCode != "" && !strings.HasPrefix(eleCode, "w.WriteString") && !strings.HasPrefix(eleCode, "// ") {
+		eleCode = fmt.Sprintf("// %s:%d\n%s", c.file, e.Line, eleCode)
 	}
 	if e.VSlot != nil {
 		var namedSlotCode2 map[string]string
-		eleCode, namedSlotCode2 = genVSlot(e.VSlot, eleCode)
+		eleCode, namedSlotCode2 = genVSlot(e.VSlot, eleCode, c.ScopeKey)
 		for i, v := range namedSlotCode2 {
 			namedSlotCode[i] = v
 		}
@@ -532,49 +855,469 @@ func (c *Compiler) GenEleCode(e *VueElement) (code string, namedSlotCode map[str
 	return eleCode, namedSlotCode
 }
 
-// vIf处理if节点与elseif/else节点, 会返回elseif节点的namedSlotCode
-func genVIf(e *VIf, srcCode string, c *Compiler) (code string, namedSlotCode map[string]string) {
-	// 自己的conditions
-	condition, err := ast.Js2Go(e.Condition, ScopeKey)
+// 合并子节点的具名插槽, 同名的插槽(如多个v-region:name节点)按文档顺序依次输出
+func mergeNamedSlotCode(dst, src map[string]string) {
+	for k, v := range src {
+		if prev, ok := dst[k]; ok {
+			v = fmt.Sprintf(`func(w Writer, props Props){
+(%s)(w, props)
+(%s)(w, props)
+}`, prev, v)
+		}
+		dst[k] = v
+	}
+}
+
+// 内容以换行开头时多输出一个换行, 见leadingNewlineElements
+func genLeadingNewline(srcCode string) (code string) {
+	return fmt.Sprintf(`
+keepLeadingNewline(r, w, func(w Writer) {
+  %s
+})
+`, srcCode)
+}
+
+// <title>节点的内容会被收集, 见Render.RenderFull
+func genTitle(srcCode string) (code string) {
+	return fmt.Sprintf(`
+collectTitle(r, w, func(w Writer) {
+  %s
+})
+`, srcCode)
+}
+
+// v-head: 节点渲染到Render.Head中, 而不是原位置
+// title只会保留一个, meta按name/property去重, 后渲染的(如子组件中的)覆盖先渲染的
+func genVHead(e *VueElement, srcCode string, scopeKey string, cacheExpr bool) (code string) {
+	keyCode := `""`
+	if e.TagName == "title" {
+		keyCode = `"title"`
+	} else {
+		for _, k := range []string{"name", "property"} {
+			if v, ok := e.Props.Get(k); ok {
+				valCode, err := js2Go(v, scopeKey, cacheExpr)
+				if err != nil {
+					panic(err)
+				}
+				keyCode = fmt.Sprintf(`"%s:%s="+interfaceToStr(%s)`, e.TagName, k, valCode)
+				break
+			}
+			found := false
+			for _, a := range e.Attrs {
+				if a.Key == k {
+					keyCode = strconv.Quote(fmt.Sprintf("%s:%s=%s", e.TagName, k, a.Val))
+					found = true
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+	}
+
+	return fmt.Sprintf(`
+collectHead(r, %s, func(w Writer) {
+  %s
+})
+`, keyCode, srcCode)
+}
+
+// 校验v-for节点上的key表达式: 必须是合法的表达式, 并且需要引用item或index变量
+func validateKey(e *VFor, key string, scopeKey string) {
+	_, err := ast.Js2Go(key, scopeKey)
 	if err != nil {
-		panic(err)
+		panic(&ParseError{Msg: fmt.Sprintf("bad :key expression %q: %v", key, err), Category: CategoryKey})
+	}
+
+	names, err := ast.Identifiers(key)
+	if err != nil {
+		panic(&ParseError{Msg: fmt.Sprintf("bad :key expression %q: %v", key, err), Category: CategoryKey})
 	}
+	for _, n := range names {
+		if n == e.ItemKey || n == e.IndexKey {
+			return
+		}
+	}
+
+	panic(&ParseError{Msg: fmt.Sprintf(":key expression %q does not reference v-for variable %q or %q", key, e.ItemKey, e.IndexKey), Category: CategoryKey})
+}
+
+// 需要清理的url属性, 见Compiler.SanitizeURL
+var urlAttrs = builtinSet("urlAttrs")
+
+// 在url属性的表达式外添加运行时的$sanitizeURL方法, 返回修改后的副本, 不修改原节点
+func sanitizeURLProps(e *VueElement) *VueElement {
+	var n *VueElement
+	for i, p := range e.Props {
+		if urlAttrs[p.Key] && !strings.HasPrefix(p.Val, "$sanitizeURL(") {
+			if n == nil {
+				cp := *e
+				cp.Props = append(Props(nil), e.Props...)
+				n = &cp
+			}
+			n.Props[i].Val = fmt.Sprintf("$sanitizeURL(%s)", p.Val)
+		}
+	}
+	if n == nil {
+		return e
+	}
+	return n
+}
+
+// 组件是否可以被内联, 见Compiler.InlineSize
+func (c *Compiler) inlinable(name string) bool {
+	if c.InlineSize <= 0 {
+		return false
+	}
+	f, ok := c.Files[name]
+	if !ok || f.Element == nil {
+		return false
+	}
+	return countNodes(f.Element) <= c.InlineSize && !c.recursive(name)
+}
+
+// 组件是否直接或间接地使用了自身
+func (c *Compiler) recursive(name string) bool {
+	visited := map[string]bool{}
+	var walk func(e *VueElement) bool
+	walk = func(e *VueElement) bool {
+		if n, ok := c.component(e.TagName); ok {
+			if n == name {
+				return true
+			}
+			if !visited[n] {
+				visited[n] = true
+				if f, ok := c.Files[n]; ok && f.Element != nil && walk(f.Element) {
+					return true
+				}
+			}
+		}
+		for _, ch := range e.Children {
+			if walk(ch) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return walk(c.Files[name].Element)
+}
+
+func countNodes(e *VueElement) int {
+	n := 1
+	for _, ch := range e.Children {
+		n += countNodes(ch)
+	}
+	return n
+}
+
+// 生成内联组件的代码, 与组件方法的代码相同, 只是options与scope是在代码块中声明的
+// 组件会被重新解析, 因为生成代码时会修改VueElement
+func (c *Compiler) inlineComponent(name string, optionsCode string) (code string, ok bool) {
+	if !c.inlinable(name) {
+		return
+	}
+	ve, err := c.parseVue(c.Files[name].Path)
+	if err != nil {
+		return
+	}
+
+	// 使用组件自身的文件生成v-memo的key与SourceMap, 与组件方法的代码一致
+	// 组件有自己的作用域, 不能读取调用处声明的局部变量
+	file, memoCount, localVars := c.file, c.memoCount, c.localVars
+	c.file, c.memoCount, c.localVars = filepath.ToSlash(filepath.Clean(c.Files[name].Path)), 0, nil
+	bodyCode, _ := c.GenEleCode(ve)
+	c.file, c.memoCount, c.localVars = file, memoCount, localVars
+
+	code = fmt.Sprintf(`{
+options := %s
+if !r.canceled() && r.enter(%q, options) {
+w, hookDone := r.hookComponent(%q, w)
+%s := extendScope(r.Global, options.Props.data)
+_ = %s
+%s
+hookDone()
+}
+}`, optionsCode, name, name, c.ScopeKey, c.ScopeKey, bodyCode)
+	return code, true
+}
+
+// 按编译选项解析.vue文件
+func (c *Compiler) parseVue(filename string) (*VueElement, error) {
+	return VueElementParser{
+		StrictAttrs:  c.StrictAttrs,
+		Warn:         log.Warningf,
+		Source:       c.Source,
+		KeepComments: c.KeepComments,
+	}.ParseFile(filename)
+}
+
+func (c *Compiler) ext() string {
+	if c.Ext == "" {
+		return ".vue"
+	}
+	return c.Ext
+}
+
+// 计算v-build-if的条件, 支持flag与!flag
+func (c *Compiler) buildIf(cond string) bool {
+	if strings.HasPrefix(cond, "!") {
+		return !c.BuildFlags[strings.TrimSpace(cond[1:])]
+	}
+	return c.BuildFlags[cond]
+}
+
+// 生成传递给组件(自定义组件, Go代码实现的组件, 自带组件)的Options代码
+func (c *Compiler) genOptionsCode(e *VueElement, attrs []Attribute, props Props, defaultSlotCode string, namedSlotCode map[string]string) string {
+	options := OptionsGen{
+		Class:           e.Class,
+		Attrs:           attrs,
+		AttrProps:       e.AttrProps,
+		Props:           props,
+		Style:           e.Style,
+		DefaultSlotCode: defaultSlotCode,
+		NamedSlotCode:   namedSlotCode,
+		Directives:      e.Directives,
+		VBind:           e.VBind,
+		ScopeKey:        c.ScopeKey,
+		CacheExpr:       c.CacheExpr,
+	}
+	return options.ToGoCode()
+}
+
+// 生成传递给组件的Options代码, 没有值的属性作为值为true的prop传递
+func (c *Compiler) genComponentOptionsCode(e *VueElement, defaultSlotCode string, namedSlotCode map[string]string) string {
+	attrs, props := componentAttrs(e)
+	return c.genOptionsCode(e, attrs, props, defaultSlotCode, namedSlotCode)
+}
+
+// 检查节点上的表达式是否读取了不允许的变量, 调用了不允许的方法, 或者过于复杂
+func (c *Compiler) checkExprs(e *VueElement) {
+	if c.AllowedVars != nil {
+		c.checkVars(e)
+	}
+	if c.AllowedFuncs != nil {
+		c.checkCalls(e)
+	}
+	if c.MaxExprDepth > 0 || c.MaxExprNodes > 0 {
+		c.checkComplexity(e)
+	}
+}
+
+// 检查节点上的所有表达式, 如果调用了不在AllowedFuncs中的方法则panic
+func (c *Compiler) checkCalls(e *VueElement) {
+	for _, exp := range nodeExprs(e) {
+		names, err := ast.Calls(exp)
+		if err != nil {
+			panic(err)
+		}
+		for _, n := range names {
+			if !c.AllowedFuncs[n] {
+				panic(&ParseError{Msg: fmt.Sprintf("function %q is not allowed in expression %q", n, exp), Category: CategoryForbiddenFunc})
+			}
+		}
+	}
+}
+
+// 检查节点上的所有表达式, 如果读取了不在AllowedVars中(也不是声明的局部变量)的变量则panic
+func (c *Compiler) checkVars(e *VueElement) {
+	for _, exp := range nodeExprs(e) {
+		names, err := ast.Vars(exp)
+		if err != nil {
+			panic(err)
+		}
+		for _, n := range names {
+			if !c.AllowedVars[n] && !c.isLocalVar(n) && n != "undefined" {
+				panic(&ParseError{Msg: fmt.Sprintf("variable %q is not allowed in expression %q", n, exp), Category: CategoryForbiddenVar})
+			}
+		}
+	}
+}
+
+func (c *Compiler) isLocalVar(name string) bool {
+	for _, v := range c.localVars {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 节点上声明的变量: v-for的item与index, v-let, v-slot的props
+func declaredVars(e *VueElement) (vars []string) {
+	if e.VFor != nil {
+		vars = append(vars, e.VFor.ItemKey, e.VFor.IndexKey)
+	}
+	for _, l := range e.VLet {
+		vars = append(vars, l.Name)
+	}
+	if e.VSlot != nil {
+		vars = append(vars, e.VSlot.PropsKey)
+	}
+	return
+}
+
+// 检查节点上的所有表达式, 如果超过了MaxExprDepth或MaxExprNodes则panic
+func (c *Compiler) checkComplexity(e *VueElement) {
+	for _, exp := range nodeExprs(e) {
+		if err := ast.CheckComplexity(exp, c.MaxExprDepth, c.MaxExprNodes); err != nil {
+			panic(&ParseError{Msg: err.Error(), Category: CategoryComplexity})
+		}
+	}
+}
+
+// 节点上的所有(非空)表达式, 包括插值, 属性, 指令等
+func nodeExprs(e *VueElement) (exps []string) {
+	switch e.NodeType {
+	case parser.TextNode:
+		for _, s := range interpolation.FindAllString(e.Text, -1) {
+			ss := splitFilter(s[2 : len(s)-2])
+			exps = append(exps, ss[0])
+			// 过滤器的参数
+			for _, f := range ss[1:] {
+				if start := strings.Index(f, "("); start != -1 && strings.HasSuffix(f, ")") {
+					exps = append(exps, "["+f[start+1:len(f)-1]+"]")
+				}
+			}
+		}
+	case parser.ElementNode:
+		for _, p := range e.Props {
+			exps = append(exps, p.Val)
+		}
+		for _, p := range e.AttrProps {
+			exps = append(exps, p.Val)
+		}
+		for _, d := range e.Directives {
+			exps = append(exps, d.Value)
+		}
+		if e.VIf != nil {
+			exps = append(exps, e.VIf.Condition)
+			for _, v := range e.VIf.ElseIf {
+				exps = append(exps, v.Condition)
+			}
+		}
+		if e.VFor != nil {
+			exps = append(exps, e.VFor.ArrayKey)
+		}
+		for _, l := range e.VLet {
+			exps = append(exps, l.Value)
+		}
+		exps = append(exps, e.VBind, e.VHtml, e.VText, e.Memo)
+	}
+
+	n := 0
+	for _, exp := range exps {
+		if strings.Trim(exp, " ") != "" {
+			exps[n] = exp
+			n++
+		}
+	}
+	return exps[:n]
+}
+
+// vIf处理if节点与elseif/else节点, 会返回elseif节点的namedSlotCode
+// 条件是字面量(如v-if="false")时在编译期计算: 为false的分支不会生成代码, 为true的分支之后的分支也不会生成代码
+func genVIf(e *VIf, srcCode string, c *Compiler) (code string, namedSlotCode map[string]string) {
 	namedSlotCode = map[string]string{}
+	// 是否已经输出了if, 之后的分支需要使用else
+	opened := false
+	for i := -1; i < len(e.ElseIf); i++ {
+		// i为-1时是if节点自己
+		cond := e.Condition
+		var elseIf *ElseIf
+		if i >= 0 {
+			elseIf = e.ElseIf[i]
+			cond = elseIf.Condition
+		}
+
+		value, isConst := true, true
+		if elseIf == nil || elseIf.Types == "elseif" {
+			value, isConst = constCondition(cond)
+		}
+		if isConst && !value {
+			continue
+		}
+
+		eleCode := srcCode
+		if elseIf != nil {
+			var namedSlotCode2 map[string]string
+			eleCode, namedSlotCode2 = c.GenEleCode(elseIf.VueElement)
+			for k, v := range namedSlotCode2 {
+				namedSlotCode[k] = v
+			}
+			// 分支之前的注释
+			for i := len(elseIf.Comments) - 1; i >= 0; i-- {
+				if commentCode, _ := c.GenEleCode(elseIf.Comments[i]); commentCode != "" {
+					eleCode = commentCode + "\n" + eleCode
+				}
+			}
+		}
 
-	// open if
-	code = fmt.Sprintf(`
-if interfaceToBool(%s) { %s`, condition, srcCode)
-	// 继续处理else节点
-	for _, v := range e.ElseIf {
-		eleCode, namedSlotCode2 := c.GenEleCode(v.VueElement)
-		for k, v := range namedSlotCode2 {
-			namedSlotCode[k] = v
-		}
-		switch v.Types {
-		case "else":
-			code += fmt.Sprintf(`} else { %s`, eleCode)
-		case "elseif":
-			condition, err := ast.Js2Go(v.Condition, ScopeKey)
-			if err != nil {
-				panic(err)
+		if isConst {
+			// 条件始终为true, 之后的分支不会被渲染
+			if opened {
+				code += fmt.Sprintf(`} else { %s`, eleCode)
+			} else {
+				code = fmt.Sprintf(`
+{ %s
+}`, eleCode)
+				return
 			}
+			break
+		}
+
+		condition, err := js2Go(cond, c.ScopeKey, c.CacheExpr)
+		if err != nil {
+			panic(err)
+		}
+		if opened {
 			code += fmt.Sprintf(`} else if interfaceToBool(%s) { %s`, condition, eleCode)
+		} else {
+			code = fmt.Sprintf(`
+if interfaceToBool(%s) { %s`, condition, eleCode)
+			opened = true
 		}
 	}
 
 	// close if
-	code += `
+	if opened {
+		code += `
 }`
+	}
 	return
 }
 
-func genVSlot(e *VSlot, srcCode string) (code string, namedSlotCode map[string]string) {
+// 在编译期计算v-if的条件, 只处理由字面量组成的条件(如false, 0, ''), 结果和运行时的interfaceToBool一致
+// ok为false表示需要在运行时计算
+func constCondition(cond string) (value bool, ok bool) {
+	switch strings.TrimSpace(cond) {
+	case "true":
+		return true, true
+	case "false", "null", "undefined":
+		return false, true
+	}
+	v, ok := ast.ConstFold(cond)
+	if !ok {
+		return
+	}
+	switch v := v.(type) {
+	case float64:
+		return v != 0, true
+	case string:
+		return v != "" && v != "false" && v != "0", true
+	}
+	return false, false
+}
+
+func genVSlot(e *VSlot, srcCode string, scopeKey string) (code string, namedSlotCode map[string]string) {
 	namedSlotCode = map[string]string{
 		e.SlotName: fmt.Sprintf(`func(w Writer, props Props){
-	%s := extendScope(%s, map[string]interface{}{"%s": props})
-_ = %s
+	%s := acquireScope(r, %s)
+%s.Set("%s", props.Map())
 %s
-}`, ScopeKey, ScopeKey, e.PropsKey, ScopeKey, srcCode),
+releaseScope(r, %s)
+}`, scopeKey, scopeKey, scopeKey, e.PropsKey, srcCode, scopeKey),
 	}
 
 	// 插槽会将原来的子代码去掉, 并将代码放在namedSlot里.
@@ -582,50 +1325,215 @@ _ = %s
 	return
 }
 
-func genVFor(e *VFor, srcCode string) (code string) {
+func genVFor(e *VFor, srcCode string, scopeKey string, cacheExpr bool) (code string) {
 	vfArray := e.ArrayKey
 	vfItem := e.ItemKey
 	vfIndex := e.IndexKey
-	vfArrayCode, err := ast.Js2Go(vfArray, ScopeKey)
+	vfArrayCode, err := js2Go(vfArray, scopeKey, cacheExpr)
 	if err != nil {
 		panic(err)
 	}
+	rangeFunc := "forRange"
+	if e.OneBased {
+		rangeFunc = "forRangeOne"
+	}
 
 	// 将自己for, 将子代码的data字段覆盖, 实现作用域的修改
+	// 每次循环的作用域来自对象池, 在循环结束时归还
+	return fmt.Sprintf(`
+  %s(r, %s, func(index interface{}, item interface{}) {
+    %s := acquireScope(r, %s)
+    %s.Set("%s", index)
+    %s.Set("%s", item)
+    %s
+    releaseScope(r, %s)
+  })
+`, rangeFunc, vfArrayCode, scopeKey, scopeKey, scopeKey, vfIndex, scopeKey, vfItem, srcCode, scopeKey)
+}
+
+// v-join: 除了第一次渲染的循环, 在每次循环的内容前输出分隔符
+func genVJoin(sep string, srcCode string) (code string) {
+	return fmt.Sprintf(`
+if vJoined {
+  w.WriteString(%s)
+}
+vJoined = true
+%s
+`, strconv.Quote(textEscaper.Replace(sep)), srcCode)
+}
+
+// v-memo: 依赖的值不变时复用缓存的渲染结果, 缓存在RenderCreator中, 会在多次渲染(请求)之间共享
+func genVMemo(id string, deps string, srcCode string, scopeKey string, cacheExpr bool) (code string) {
+	depsCode, err := js2Go(deps, scopeKey, cacheExpr)
+	if err != nil {
+		panic(err)
+	}
+
 	return fmt.Sprintf(`
-  for index, item := range interface2Slice(%s) {
-    func(xscope *Scope){
-        %s := extendScope(xscope, map[string]interface{}{
-          "%s": index,
-          "%s": item,
-        })
-		_ = %s
-		%s
-    }(%s)
-  }
-`, vfArrayCode, ScopeKey, vfIndex, vfItem, ScopeKey, srcCode, ScopeKey)
-}
-
-func genVHtml(value string) (code string) {
-	goCode, err := ast.Js2Go(value, ScopeKey)
+memo(r, %s, %s, w, func(w Writer) {
+  %s
+})
+`, strconv.Quote(id), depsCode, srcCode)
+}
+
+// 和v-for一样, 使用新的作用域来声明变量
+func genVLet(lets []VLet, srcCode string, scopeKey string, cacheExpr bool) (code string) {
+	data := ""
+	for _, l := range lets {
+		valueCode, err := js2Go(l.Value, scopeKey, cacheExpr)
+		if err != nil {
+			panic(err)
+		}
+		data += fmt.Sprintf("\n\"%s\": %s,", l.Name, valueCode)
+	}
+
+	return fmt.Sprintf(`
+func(xscope *Scope){
+  %s := extendScope(xscope, map[string]interface{}{%s
+  })
+  _ = %s
+  %s
+}(%s)
+`, scopeKey, data, scopeKey, srcCode, scopeKey)
+}
+
+func genVHtml(value string, scopeKey string, cacheExpr bool) (code string) {
+	goCode, err := js2Go(value, scopeKey, cacheExpr)
 	if err != nil {
 		panic(err)
 	}
 	return fmt.Sprintf(`w.WriteString(interfaceToStr(%s))`, goCode)
 }
 
-func genVText(value string) (code string) {
-	goCode, err := ast.Js2Go(value, ScopeKey)
+func genVText(value string, scopeKey string, cacheExpr bool) (code string) {
+	goCode, err := js2Go(value, scopeKey, cacheExpr)
 	if err != nil {
 		panic(err)
 	}
 	return fmt.Sprintf(`w.WriteString(interfaceToStr(%s, true))`, goCode)
 }
 
+// 移除只在客户端生效的指令, 如果开启了Hydrate则将它们作为静态attr输出
+// 返回修改后的副本, 不修改e, 因为解析的节点可能会被多次编译(如watch与内联组件)
+func (c *Compiler) stripClientDirectives(e *VueElement) *VueElement {
+	if len(c.ClientDirectives) == 0 || len(e.Directives) == 0 {
+		return e
+	}
+
+	n := *e
+	n.Directives = e.Directives[:0:0]
+	n.Attrs = e.Attrs[:len(e.Attrs):len(e.Attrs)]
+	for _, d := range e.Directives {
+		if !c.ClientDirectives[d.Name] {
+			n.Directives = append(n.Directives, d)
+			continue
+		}
+		if c.Hydrate {
+			key := d.Name
+			if d.Arg != "" {
+				key += ":" + d.Arg
+			}
+			// 和其他静态attr一样, 值在输出时编码
+			n.Attrs = append(n.Attrs, Attribute{Key: key, Val: d.Value})
+		}
+	}
+	return &n
+}
+
+// 开启了Hydrate时将v-on作为静态attr输出(如v-on:click.prevent="submit(id)"), 供客户端激活时绑定事件, 否则v-on会被丢弃
+// 和stripClientDirectives一样返回副本
+func (c *Compiler) hydrateEvents(e *VueElement) *VueElement {
+	if !c.Hydrate || len(e.VOn) == 0 {
+		return e
+	}
+
+	n := *e
+	n.Attrs = e.Attrs[:len(e.Attrs):len(e.Attrs)]
+	for _, on := range e.VOn {
+		n.Attrs = append(n.Attrs, Attribute{Key: on.Key(), Val: on.Exp})
+	}
+	return &n
+}
+
+// 生成代码中使用的局部变量, 见CheckScopeKey
+var generatedLocals = map[string]bool{
+	"r": true, "w": true, "options": true, "hookDone": true, "xscope": true,
+	"index": true, "item": true, "props": true, "vJoined": true,
+}
+
+// 运行时代码(builtin.go)的语法树
+var builtinFile = func() *goast.File {
+	f, err := goparser.ParseFile(token.NewFileSet(), "builtin.go", "package main\n"+builtinCode, 0)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}()
+
+// 运行时代码中的包级名字, 如Render与interfaceToStr
+var builtinNames = func() map[string]bool {
+	names := map[string]bool{}
+	for name := range builtinFile.Scope.Objects {
+		names[name] = true
+	}
+	for _, imp := range builtinFile.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		names[path[strings.LastIndex(path, "/")+1:]] = true
+	}
+	return names
+}()
+
+// 运行时代码中名为name的map[string]bool变量的值, 使编译时与运行时使用同一份数据
+func builtinSet(name string) map[string]bool {
+	obj := builtinFile.Scope.Lookup(name)
+	if obj == nil {
+		panic(fmt.Sprintf("%s not found in builtin code", name))
+	}
+	set := map[string]bool{}
+	for _, elt := range obj.Decl.(*goast.ValueSpec).Values[0].(*goast.CompositeLit).Elts {
+		kv := elt.(*goast.KeyValueExpr)
+		key, _ := strconv.Unquote(kv.Key.(*goast.BasicLit).Value)
+		set[key] = kv.Value.(*goast.Ident).Name == "true"
+	}
+	return set
+}
+
+// CheckScopeKey 检查key是否可以作为生成代码中作用域变量的名字, 见Compiler.ScopeKey
+// key必须是go的标识符, 并且不能与go的关键字, 内置的类型与方法, 生成代码中的局部变量以及运行时代码中的名字相同, 否则生成的代码无法编译
+func CheckScopeKey(key string) error {
+	switch {
+	case token.IsKeyword(key):
+		return fmt.Errorf("invalid scope key %q: it is a go keyword", key)
+	case !token.IsIdentifier(key) || key == "_":
+		return fmt.Errorf("invalid scope key %q: it is not a go identifier", key)
+	case types.Universe.Lookup(key) != nil:
+		return fmt.Errorf("invalid scope key %q: it is a predeclared go identifier", key)
+	case generatedLocals[key] || builtinNames[key] || strings.HasPrefix(key, "xx_"):
+		return fmt.Errorf("invalid scope key %q: it is used by the generated code", key)
+	}
+	return nil
+}
+
 func NewCompiler() *Compiler {
-	return &Compiler{
-		Components: map[string]string{},
+	c := &Compiler{
+		Components:     map[string]string{},
+		CodeComponents: map[string]string{},
+		Files:          map[string]*VueFile{},
+		ScopeKey:       ScopeKey,
+	}
+
+	c.VoidElements = copyElements(voidElements)
+	c.BlockElements = copyElements(blockElements)
+	c.RawTextElements = copyElements(rawTextElements)
+	return c
+}
+
+func copyElements(m map[string]bool) map[string]bool {
+	c := make(map[string]bool, len(m))
+	for k, v := range m {
+		c[k] = v
 	}
+	return c
 }
 
 func (a *Compiler) AddComponent(name string) {
@@ -635,31 +1543,268 @@ func (a *Compiler) AddComponent(name string) {
 	compName := sheXing2TuoFeng(name)
 	a.Components[tagName] = compName
 	a.Components[compName] = compName
+	a.componentIndex = foldIndex(a.componentIndex, tagName, compName)
+}
+
+// 注册使用Go代码实现的组件, 使用这个组件的地方会在运行时调用RenderCreator.Component注册的方法(以驼峰名字注册), 而不是模板生成的代码.
+// 方法会收到和模板组件一样的Options(包括props与插槽).
+func (a *Compiler) AddCodeComponent(name string) {
+	compName := sheXing2TuoFeng(name)
+	tagName := tuoFeng2SheXing(name)
+	a.CodeComponents[tagName] = compName
+	a.CodeComponents[compName] = compName
+	a.codeComponentIndex = foldIndex(a.codeComponentIndex, tagName, compName)
+}
+
+// 重新建立组件的大小写无关索引, 直接修改了Components/CodeComponents(而不是通过AddComponent)后需要调用
+func (a *Compiler) reindexComponents() {
+	a.componentIndex = foldIndex(nil, getSortedKey(a.Components)...)
+	a.codeComponentIndex = foldIndex(nil, getSortedKey(a.CodeComponents)...)
+}
+
+// 将组件的key按小写加入索引, 多个key的小写相同时保留排序最小的一个, 保证每次编译的代码都一样
+func foldIndex(index map[string]string, keys ...string) map[string]string {
+	if index == nil {
+		index = map[string]string{}
+	}
+	for _, k := range keys {
+		lower := strings.ToLower(k)
+		if old, ok := index[lower]; !ok || k < old {
+			index[lower] = k
+		}
+	}
+	return index
+}
+
+// 查找tag对应的组件名(驼峰)
+// 除了注册时的蛇形与驼峰写法, 还不区分大小写, 如注册了Foo, 那么<foo> <Foo> <FOO>都能匹配到.
+func (a *Compiler) component(tagName string) (compName string, exist bool) {
+	return lookupComponent(a.Components, a.componentIndex, tagName)
+}
+
+func (a *Compiler) codeComponent(tagName string) (compName string, exist bool) {
+	return lookupComponent(a.CodeComponents, a.codeComponentIndex, tagName)
+}
+
+// index是components的key的小写索引, 见foldIndex
+func lookupComponent(components map[string]string, index map[string]string, tagName string) (compName string, exist bool) {
+	if compName, exist = components[tagName]; exist {
+		return
+	}
+	if compName, exist = components[tuoFeng2SheXing(tagName)]; exist {
+		return
+	}
+
+	if k, ok := index[strings.ToLower(tagName)]; ok {
+		compName, exist = components[k]
+	}
+	return
+}
+
+// 匹配文本中的{{}}插值
+var interpolation = regexp.MustCompile(`{{.+?}}`)
+
+// js代码转为go代码, cacheExpr为true时多级路径的取值使用cachedGet, 见Compiler.CacheExpr
+func js2Go(code string, scopeKey string, cacheExpr bool) (string, error) {
+	if cacheExpr {
+		return ast.Js2GoCached(code, scopeKey)
+	}
+	return ast.Js2Go(code, scopeKey)
 }
 
 // 处理 Mustache {{}} 插值
 // 生成代码（字符串类型）, .e.g: "123" + interfaceToStr(scope.Get("total"),true)
-func injectVal(src string) (to string) {
-	reg := regexp.MustCompile(`{{.+?}}`)
-
-	src = reg.ReplaceAllStringFunc(src, func(s string) string {
+// emptyBool为true时bool值输出为空字符串, 见Compiler.EmptyBool
+func injectVal(src string, scopeKey string, cacheExpr bool, emptyBool bool) (to string) {
+	src = interpolation.ReplaceAllStringFunc(src, func(s string) string {
 		key := s[2 : len(s)-2]
 
-		goCode, err := ast.Js2Go(key, ScopeKey)
+		// 只由字面量组成的表达式在编译期计算, 直接输出为字符串
+		if v, ok := ast.ConstFold(key); ok && len(splitFilter(key)) == 1 {
+			str := strconv.Quote(html.EscapeString(fmt.Sprintf("%v", v)))
+			return str[1 : len(str)-1]
+		}
+
+		goCode, err := genFilterExpCode(key, scopeKey, cacheExpr)
 		if err != nil {
 			panic(err)
 		}
+		if emptyBool {
+			goCode = fmt.Sprintf("emptyBool(%s)", goCode)
+		}
 		return fmt.Sprintf(`"+interfaceToStr(%s, true)+"`, goCode)
 	})
 
+	// 相邻的插值之间不需要空字符串: {{a}}{{b}} => a+b
+	src = strings.Replace(src, `+""+`, `+`, -1)
 	src = strings.TrimPrefix(src, `""+`)
 	src = strings.TrimSuffix(src, `+""`)
 	return src
 }
 
+// 文本是否只由一个插值与前后的空白组成, 如"  {{ x }}  "
+func isSoleInterpolation(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{{") && strings.HasSuffix(s, "}}") && strings.Count(s, "{{") == 1
+}
+
+// 检查文本中是否有没有闭合的{{, 常见于插值被标签分隔的情况, 如{{ a <b>}}</b>
+// 每个文本节点单独处理插值, 这样的{{无法被处理. script/style中的文本也需要检查, 否则没有闭合的{{会被丢弃
+func checkInterpolation(src string) {
+	rest := src
+	for {
+		i := strings.Index(rest, "{{")
+		if i == -1 {
+			return
+		}
+		j := strings.Index(rest[i+2:], "}}")
+		if j == -1 {
+			snippet := rest[i:]
+			if len(snippet) > 20 {
+				snippet = snippet[:20] + "..."
+			}
+			panic(&ParseError{Msg: fmt.Sprintf("unterminated interpolation %s, missing \"}}\" (interpolations can not span across elements)", snippet), Category: CategoryInterpolation})
+		}
+		rest = rest[i+2+j+2:]
+	}
+}
+
+// 生成带过滤器的表达式代码
+// 如 value | raw | date('2006-01-02'), 生成 execFilter(r, "date", execFilter(r, "raw", scope.Get("value")), "2006-01-02")
+func genFilterExpCode(exp string, scopeKey string, cacheExpr bool) (goCode string, err error) {
+	ss := splitFilter(exp)
+	goCode, err = js2Go(ss[0], scopeKey, cacheExpr)
+	if err != nil {
+		return
+	}
+
+	for _, f := range ss[1:] {
+		name := f
+		argsCode := ""
+		// filter(a, b)
+		if start := strings.Index(f, "("); start != -1 && strings.HasSuffix(f, ")") {
+			name = strings.Trim(f[:start], " ")
+			args := strings.Trim(f[start+1:len(f)-1], " ")
+			if args != "" {
+				// 用数组来解析多个参数
+				argsCode, err = js2Go("["+args+"]", scopeKey, cacheExpr)
+				if err != nil {
+					return
+				}
+				argsCode = ", " + argsCode + "..."
+			}
+		}
+
+		goCode = fmt.Sprintf(`execFilter(r, "%s", %s%s)`, name, goCode, argsCode)
+	}
+
+	return
+}
+
+// 将表达式按照过滤器的管道符'|'分割, 第一项是表达式, 之后是过滤器
+// 会跳过字符串与括号中的'|', 以及逻辑运算符'||'
+func splitFilter(exp string) (ss []string) {
+	var quote byte
+	depth := 0
+	last := 0
+	for i := 0; i < len(exp); i++ {
+		c := exp[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '|':
+			if depth != 0 {
+				continue
+			}
+			if i+1 < len(exp) && exp[i+1] == '|' {
+				i++
+				continue
+			}
+			ss = append(ss, strings.Trim(exp[last:i], " "))
+			last = i + 1
+		}
+	}
+	ss = append(ss, strings.Trim(exp[last:], " "))
+
+	return
+}
+
 // 包裹字符串
 // 需要处理如: 将"变为 \"
 // 跳过处理{{表达式中的字符串.
+var textEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\u00a0", "&nbsp;",
+)
+
+var (
+	trimLeftMarker  = regexp.MustCompile(`\s*{{-(\s)`)
+	trimRightMarker = regexp.MustCompile(`(\s)-}}\s*`)
+)
+
+// 处理插值中的空白控制符: "a {{- x }}"会去掉x左边的空白, "{{ x -}} b"会去掉x右边的空白
+// 和go template一样, -与表达式之间需要有空白, 以区分{{-1}}这样的表达式
+func trimMarkers(s string) string {
+	if !strings.Contains(s, "{{-") && !strings.Contains(s, "-}}") {
+		return s
+	}
+	s = trimLeftMarker.ReplaceAllString(s, "{{$1")
+	s = trimRightMarker.ReplaceAllString(s, "$1}}")
+	return s
+}
+
+// 生成文本节点的代码
+// 将文本处理成go代码的字符串写法: "xxx", 注意{{表达式中的"不应该被处理, 因为这是js代码, 需要解析成为JS AST.
+// 解析html时实体已被解码(如&amp;会变成&), 所以需要重新编码, 否则&lt;b&gt;会被输出为<b>.
+// raw为true时是RawTextElements(如script/style)中的文本, 不是html, 不需要编码实体, 否则a < b会被输出为a &lt; b
+func (c *Compiler) genTextCode(text string, raw bool) string {
+	checkInterpolation(text)
+	if raw {
+		// script/style中的插值不使用EmptyBool, 如var on = {{ on }}, 输出空字符串会得到错误的js
+		return fmt.Sprintf(`w.WriteString(%s)`, injectVal(safeStringCode(trimMarkers(text)), c.ScopeKey, c.CacheExpr, false))
+	}
+
+	code := safeStringCode(escapeText(trimMarkers(text)))
+	// 处理变量
+	code = injectVal(code, c.ScopeKey, c.CacheExpr, c.EmptyBool)
+	return fmt.Sprintf(`w.WriteString(%s)`, code)
+}
+
+// 重新编码文本节点中的html实体, 跳过{{表达式
+func escapeText(s string) string {
+	var t strings.Builder
+	ss := strings.Split(s, "{{")
+	// 第一个{{之前的都是文本, 其中单独的}}也是文本
+	t.WriteString(textEscaper.Replace(ss[0]))
+	for _, v := range ss[1:] {
+		end := strings.Index(v, "}}")
+		if end == -1 {
+			// 没有闭合的{{, 见checkInterpolation
+			t.WriteString(textEscaper.Replace("{{" + v))
+			continue
+		}
+		t.WriteString("{{")
+		t.WriteString(v[:end+2])
+		t.WriteString(textEscaper.Replace(v[end+2:]))
+	}
+	return t.String()
+}
+
 func safeStringCode(s string) (to string) {
 	var t strings.Builder
 	for _, v := range strings.Split(s, "{{") {
@@ -1,6 +1,9 @@
 package vuessr
 
 import (
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/internal/pkg/html"
+	"github.com/zbysir/go-vue-ssr/pkg/vuessr/parser"
 	"strconv"
 	"strings"
 	"testing"
@@ -30,7 +33,7 @@ func TestQuote(t *testing.T) {
 
 func TestInjectVal(t *testing.T) {
 	want := `interfaceToStr(scope.Get("total"), true)`
-	x := injectVal(`{{total}}`)
+	x := injectVal(`{{total}}`, ScopeKey, false, false)
 	if x != want {
 		t.Fatalf("%s; want: %s", x, want)
 	}
@@ -42,7 +45,7 @@ func TestTextNode(t *testing.T) {
 
 	t.Log(code)
 	// 处理变量
-	code = injectVal(code)
+	code = injectVal(code, ScopeKey, false, false)
 
 	want := `interfaceToStr(scope.Get("title"), true)`
 	if code != want {
@@ -57,7 +60,7 @@ func BenchmarkAppend(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			var x strings.Builder
 			x.WriteString("123123123123" + "123123123123" + strconv.Itoa(i))
-			x.String()
+			_ = x.String()
 		}
 	})
 
@@ -68,7 +71,7 @@ func BenchmarkAppend(b *testing.B) {
 			x.WriteString("123123123123")
 			x.WriteString("123123123123")
 			x.WriteString(strconv.Itoa(i))
-			x.String()
+			_ = x.String()
 		}
 	})
 }
@@ -80,3 +83,882 @@ w.WriteString("<link rel=\"stylesheet\"href=\"//static.f.cdn-static.cn/swiper/sw
 
 	t.Log(minifyCode(src))
 }
+
+func TestInjectValFilter(t *testing.T) {
+	want := `interfaceToStr(execFilter(r, "date", execFilter(r, "raw", scope.Get("html")), []interface{}{"2006-01-02"}...), true)`
+	x := injectVal(safeStringCode(`{{ html | raw | date('2006-01-02') }}`), ScopeKey, false, false)
+	if x != want {
+		t.Fatalf("%s; want: %s", x, want)
+	}
+
+	// ||是逻辑运算符, 不是过滤器
+	want = `interfaceToStr(interfaceToBool(scope.Get("a")) || interfaceToBool(scope.Get("b")), true)`
+	x = injectVal(safeStringCode(`{{ a || b }}`), ScopeKey, false, false)
+	if x != want {
+		t.Fatalf("%s; want: %s", x, want)
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	newLi := func(key string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "li",
+			Attrs: []html.Attribute{
+				{Key: "v-for", Val: "item in items"},
+				{Key: ":key", Val: key},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.ValidateKey = true
+
+	// 正确的key不会被输出
+	code, _ := c.GenEleCode(newLi("item.id"))
+	if strings.Contains(code, "key") || strings.Contains(code, `"id"`) {
+		t.Fatalf("key should not be in output: %s", code)
+	}
+
+	// 拼写错误的key会在编译期报错
+	func() {
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Fatal("want panic for bad key")
+			}
+			if !strings.Contains(fmt.Sprint(err), "itm.id") {
+				t.Fatalf("unexpected panic: %v", err)
+			}
+		}()
+		c.GenEleCode(newLi("itm.id"))
+	}()
+
+	// 不开启校验时不会报错
+	c.ValidateKey = false
+	code, _ = c.GenEleCode(newLi("itm.id"))
+	if strings.Contains(code, "itm") {
+		t.Fatalf("key should not be in output: %s", code)
+	}
+}
+
+// 编译不修改AST, 同一个节点多次编译的结果相同
+func TestGenEleCodeKeepAST(t *testing.T) {
+	for _, attrs := range [][]html.Attribute{
+		{{Key: "title", Val: "t"}, {Key: ":href", Val: "url"}, {Key: ":data-id.attr", Val: "id"}},
+		{{Key: ":href", Val: "url"}, {Key: ":title", Val: "t"}},
+		{{Key: "title", Val: "t"}, {Key: "id", Val: "a"}},
+	} {
+		e := VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "a",
+			Attrs:    attrs,
+		})
+		props, eAttrs := fmt.Sprint(e.Props), fmt.Sprint(e.Attrs)
+
+		c := NewCompiler()
+		c.SanitizeURL = true
+		c.CanonicalAttrs = true
+		code, _ := c.GenEleCode(e)
+		if p := fmt.Sprint(e.Props); p != props {
+			t.Fatalf("props = %s; want: %s", p, props)
+		}
+		if a := fmt.Sprint(e.Attrs); a != eAttrs {
+			t.Fatalf("attrs = %s; want: %s", a, eAttrs)
+		}
+		if code2, _ := c.GenEleCode(e); code2 != code {
+			t.Fatalf("code = %s; want: %s", code2, code)
+		}
+	}
+}
+
+func TestCustomVoidElements(t *testing.T) {
+	newEle := func(tag string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  tag,
+		})
+	}
+
+	c := NewCompiler()
+	c.VoidElements = map[string]bool{"icon": true}
+
+	code, _ := c.GenEleCode(newEle("icon"))
+	if want := `w.WriteString("<icon"+""+"/>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+
+	// 默认的void元素不再自闭合
+	code, _ = c.GenEleCode(newEle("br"))
+	if want := `w.WriteString("<br"+""+"></br>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+
+	// 运行时(动态节点)使用同样的设置
+	creator := string(genCreator(c, "test"))
+	if !strings.Contains(creator, `r.VoidElements = map[string]bool{`) || !strings.Contains(creator, `"icon": true,`) {
+		t.Fatalf("creator should contain VoidElements, code: %s", creator)
+	}
+	creator = string(genCreator(NewCompiler(), "test"))
+	if strings.Contains(creator, "VoidElements") {
+		t.Fatalf("creator should not contain VoidElements by default, code: %s", creator)
+	}
+}
+
+func TestCustomRawTextAndBlockElements(t *testing.T) {
+	newEle := func(tag, text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  tag,
+			Children: []*parser.Element{
+				{NodeType: parser.TextNode, Text: text},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.RawTextElements = map[string]bool{"formula": true}
+	code, _ := c.GenEleCode(newEle("formula", "a < b"))
+	if want := `w.WriteString("<formula"+""+">")
+w.WriteString("a < b")
+w.WriteString("</formula>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+	// script不再是rawText, 但仍然输出nonce
+	code, _ = c.GenEleCode(newEle("script", "a < b"))
+	if want := `w.WriteString("<script"+""+nonceAttr(r)+">")
+w.WriteString("a &lt; b")
+w.WriteString("</script>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+
+	c = NewCompiler()
+	c.Whitespace = WhitespaceReadable
+	c.BlockElements = map[string]bool{"row": true}
+	code, _ = c.GenEleCode(newEle("row", "x"))
+	if want := "w.WriteString(\"\\n\")\n"; !strings.HasPrefix(code, want) {
+		t.Fatalf("code = %s; want a newline before <row>", code)
+	}
+	// 默认的块级元素作为行内元素处理
+	code, _ = c.GenEleCode(newEle("p", "x"))
+	if strings.Contains(code, `\n`) {
+		t.Fatalf("code = %s; want no newline around <p>", code)
+	}
+}
+
+// v-for的数组是一个完整的表达式, 而不是作用域中的一个key
+func TestGenVForArrayExpression(t *testing.T) {
+	code := genVFor(&VFor{ArrayKey: "obj.nested.list", ItemKey: "val", IndexKey: "key"}, "", ScopeKey, false)
+	want := `forRange(r, scope.Get("obj", "nested", "list"), func(index interface{}, item interface{}) {`
+	if !strings.Contains(code, want) {
+		t.Fatalf("code should contain %q, code: %s", want, code)
+	}
+}
+
+// 只由字面量组成的插值在编译期计算
+func TestInjectValConstFold(t *testing.T) {
+	cases := []struct {
+		src  string
+		want string
+	}{
+		{`{{ 2 * 3 }}`, `"6"`},
+		{`a{{ 'x' + '<' }}b`, `"ax&lt;b"`},
+		{`{{ 1 / 0 }}`, `interfaceToStr(interfaceToFloat(1) / interfaceToFloat(0), true)`},
+		{`{{ 2 * n }}`, `interfaceToStr(interfaceToFloat(2) * interfaceToFloat(scope.Get("n")), true)`},
+	}
+	for _, c := range cases {
+		x := injectVal(safeStringCode(c.src), ScopeKey, false, false)
+		if x != c.want {
+			t.Fatalf("%s: %s; want: %s", c.src, x, c.want)
+		}
+	}
+}
+
+// 相邻的插值直接拼接
+func TestInjectValAdjacent(t *testing.T) {
+	want := `interfaceToStr(scope.Get("a"), true)+interfaceToStr(scope.Get("b"), true)`
+	x := injectVal(safeStringCode(`{{ a }}{{ b }}`), ScopeKey, false, false)
+	if x != want {
+		t.Fatalf("%s; want: %s", x, want)
+	}
+
+	want = `interfaceToStr(scope.Get("a"), true)+"-"+interfaceToStr(scope.Get("b"), true)`
+	x = injectVal(safeStringCode(`{{ a }}-{{ b }}`), ScopeKey, false, false)
+	if x != want {
+		t.Fatalf("%s; want: %s", x, want)
+	}
+}
+
+// 开启EmptyBool后插值中的bool值输出为空字符串
+func TestInjectValEmptyBool(t *testing.T) {
+	want := `"done: "+interfaceToStr(scope.Get("done"), true)`
+	x := injectVal(safeStringCode(`done: {{ done }}`), ScopeKey, false, false)
+	if x != want {
+		t.Fatalf("%s; want: %s", x, want)
+	}
+
+	want = `"done: "+interfaceToStr(emptyBool(scope.Get("done")), true)`
+	x = injectVal(safeStringCode(`done: {{ done }}`), ScopeKey, false, true)
+	if x != want {
+		t.Fatalf("%s; want: %s", x, want)
+	}
+}
+
+// 开启CacheExpr后多级路径的取值使用cachedGet, 计算出的下标与方法调用不缓存
+func TestInjectValCacheExpr(t *testing.T) {
+	cases := []struct {
+		src  string
+		want string
+	}{
+		{`{{ user.profile.name }}`, `interfaceToStr(cachedGet(r, scope, "user", "profile", "name"), true)`},
+		{`{{ name }}`, `interfaceToStr(scope.Get("name"), true)`},
+		{`{{ user[key].name }}`, `interfaceToStr(scope.Get("user", interfaceToStr(scope.Get("key")), "name"), true)`},
+	}
+	for _, c := range cases {
+		x := injectVal(safeStringCode(c.src), ScopeKey, true, false)
+		if x != c.want {
+			t.Fatalf("%s: %s; want: %s", c.src, x, c.want)
+		}
+	}
+}
+
+func TestTrimInterpolation(t *testing.T) {
+	newEle := func(tag, text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  tag,
+			Children: []*parser.Element{
+				{NodeType: parser.TextNode, Text: text},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.TrimInterpolation = true
+	for _, tc := range []struct {
+		tag, text, want string
+	}{
+		// 块级元素中只有一个插值时去掉前后的空白
+		{"p", "  {{ x }}  ", `w.WriteString(interfaceToStr(scope.Get("x"), true))`},
+		{"li", "\n  {{ x }}\n", `w.WriteString(interfaceToStr(scope.Get("x"), true))`},
+		// 行内元素中的空白不受影响
+		{"span", "  {{ x }}  ", `w.WriteString("  "+interfaceToStr(scope.Get("x"), true)+"  ")`},
+		// 除了插值还有其他文本
+		{"p", " Hi {{ x }} ", `w.WriteString(" Hi "+interfaceToStr(scope.Get("x"), true)+" ")`},
+		{"pre", "  {{ x }}  ", `w.WriteString("  "+interfaceToStr(scope.Get("x"), true)+"  ")`},
+	} {
+		code, _ := c.GenEleCode(newEle(tc.tag, tc.text))
+		if !strings.Contains(code, tc.want) {
+			t.Fatalf("<%s>%q: code should contain %s: %s", tc.tag, tc.text, tc.want, code)
+		}
+	}
+
+	// 默认不去掉
+	code, _ := NewCompiler().GenEleCode(newEle("p", "  {{ x }}  "))
+	if want := `"  "+interfaceToStr(scope.Get("x"), true)+"  "`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s: %s", want, code)
+	}
+}
+
+func TestReadableText(t *testing.T) {
+	text := &parser.Element{NodeType: parser.TextNode, Text: "\n    a\n  "}
+	newEle := func(tag string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  tag,
+			Children: []*parser.Element{text},
+		})
+	}
+
+	c := NewCompiler()
+	c.Whitespace = WhitespaceReadable
+	for _, tc := range []struct {
+		tag, want string
+	}{
+		// 块级元素开头与结尾的排版空白被去掉
+		{"li", `w.WriteString("a")`},
+		// 行内元素中的空白不受影响
+		{"span", `w.WriteString("\n    a\n  ")`},
+	} {
+		e := newEle(tc.tag)
+		code, _ := c.GenEleCode(e)
+		if !strings.Contains(code, tc.want) {
+			t.Fatalf("<%s>: code should contain %s: %s", tc.tag, tc.want, code)
+		}
+		// 不修改AST
+		if e.Children[0].Text != text.Text {
+			t.Fatalf("<%s>: text changed to %q", tc.tag, e.Children[0].Text)
+		}
+	}
+}
+
+func TestClientDirectives(t *testing.T) {
+	newEle := func() *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "input",
+			Attrs: []html.Attribute{
+				{Key: "v-focus", Val: "true"},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.ClientDirectives = map[string]bool{"v-focus": true}
+
+	// 跳过客户端指令后, 节点成为静态节点, 不会调用运行时
+	code, _ := c.GenEleCode(newEle())
+	if want := `w.WriteString("<input"+""+"/>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+
+	c.Hydrate = true
+	code, _ = c.GenEleCode(newEle())
+	if want := `w.WriteString("<input"+" v-focus=\"true\""+"/>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+
+	// 未声明的指令依然在服务端运行
+	code, _ = NewCompiler().GenEleCode(newEle())
+	if !strings.Contains(code, `Name: "v-focus"`) {
+		t.Fatalf("code should contain directive, code: %s", code)
+	}
+
+	// 不修改解析的节点, 值只在输出时编码一次
+	e := VueElementParser{}.Parse(&parser.Element{
+		NodeType: parser.ElementNode,
+		TagName:  "input",
+		Attrs: []html.Attribute{
+			{Key: "v-focus", Val: `a && "b"`},
+		},
+	})
+	for i := 0; i < 2; i++ {
+		code, _ = c.GenEleCode(e)
+		if want := `v-focus=\"a &amp;&amp; &#34;b&#34;\"`; !strings.Contains(code, want) {
+			t.Fatalf("code should contain %s, code: %s", want, code)
+		}
+	}
+	if len(e.Directives) != 1 || len(e.Attrs) != 0 {
+		t.Fatalf("element should not be modified: %+v", e)
+	}
+}
+
+func TestHydrateEvents(t *testing.T) {
+	e := VueElementParser{}.Parse(&parser.Element{
+		NodeType: parser.ElementNode,
+		TagName:  "button",
+		Attrs: []html.Attribute{
+			{Key: "@click.prevent.once", Val: "submit(id)"},
+			{Key: "v-on:mouseover", Val: "hover"},
+		},
+	})
+
+	// 纯服务端渲染时丢弃事件
+	c := NewCompiler()
+	code, _ := c.GenEleCode(e)
+	if want := `w.WriteString("<button"+""+"></button>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+
+	// 输出规范化的key与修饰符, 供客户端激活
+	c.Hydrate = true
+	code, _ = c.GenEleCode(e)
+	if want := `w.WriteString("<button"+" v-on:click.prevent.once=\"submit(id)\" v-on:mouseover=\"hover\""+"></button>")`; code != want {
+		t.Fatalf("code = %s; want: %s", code, want)
+	}
+	if len(e.Attrs) != 0 {
+		t.Fatalf("element should not be modified: %+v", e)
+	}
+}
+
+func TestAllowedFuncs(t *testing.T) {
+	newEle := func(text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "p",
+			Attrs: []html.Attribute{
+				{Key: ":title", Val: "title"},
+			},
+			Children: []*parser.Element{
+				{NodeType: parser.TextNode, Text: text},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.AllowedFuncs = map[string]bool{"plural": true}
+
+	// 白名单中的方法与过滤器可以使用
+	code, _ := c.GenEleCode(newEle(`{{ plural(count, 'item', 'items') }} {{ price | number(2) }}`))
+	if !strings.Contains(code, `scope.Get("plural")`) {
+		t.Fatalf("code should call plural: %s", code)
+	}
+
+	for _, text := range []string{`{{ exec('rm') }}`, `{{ a.b.exec() }}`, `{{ a | date(now()) }}`} {
+		func() {
+			defer func() {
+				err := recover()
+				if err == nil {
+					t.Fatalf("%s: want panic for disallowed func", text)
+				}
+				if !strings.Contains(fmt.Sprint(err), "is not allowed") {
+					t.Fatalf("unexpected panic: %v", err)
+				}
+			}()
+			c.GenEleCode(newEle(text))
+		}()
+	}
+
+	// 不限制时可以调用任何方法
+	NewCompiler().GenEleCode(newEle(`{{ exec('rm') }}`))
+}
+
+func TestAllowedExprsInRawText(t *testing.T) {
+	newEle := func(text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "script",
+			Children: []*parser.Element{
+				{NodeType: parser.TextNode, Text: text},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.AllowedFuncs = map[string]bool{}
+	c.AllowedVars = map[string]bool{"count": true}
+
+	c.GenEleCode(newEle(`var count = {{ count }};`))
+
+	for _, tc := range []struct {
+		text     string
+		category ErrorCategory
+	}{
+		{`var s = {{ exec(count) }};`, CategoryForbiddenFunc},
+		{`var s = {{ secret }};`, CategoryForbiddenVar},
+	} {
+		func() {
+			defer func() {
+				err, ok := recover().(*ParseError)
+				if !ok || err.Category != tc.category {
+					t.Fatalf("%s: err = %v; want %s", tc.text, err, tc.category)
+				}
+			}()
+			c.GenEleCode(newEle(tc.text))
+		}()
+	}
+}
+
+func TestAllowedVars(t *testing.T) {
+	c := NewCompiler()
+	c.Source = MapSource{
+		"ok.vue":  `<template><ul :class="cls"><li v-for="(item, i) in list" v-let:n="item.name">{{ i }} {{ n | upper }} {{ plural(count) }}</li></ul></template>`,
+		"bad.vue": `<template><ul><li v-for="item in list">{{ item.name }} {{ secret.token }}</li></ul></template>`,
+	}
+	c.AllowedVars = map[string]bool{"cls": true, "list": true, "count": true}
+
+	// v-for/v-let声明的变量可以读取, 直接调用的方法由AllowedFuncs检查
+	ve, err := c.parseVue("ok.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.GenEleCode(ve)
+
+	ve, err = c.parseVue("bad.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err, ok := recover().(*ParseError)
+		if !ok || err.Category != CategoryForbiddenVar || !strings.Contains(err.Msg, `variable "secret" is not allowed`) {
+			t.Fatalf("err = %v; want forbidden var secret", err)
+		}
+	}()
+	c.GenEleCode(ve)
+}
+
+func TestMaxExprComplexity(t *testing.T) {
+	newEle := func(text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.TextNode,
+			Text:     text,
+		})
+	}
+
+	c := NewCompiler()
+	c.MaxExprDepth = 8
+	c.MaxExprNodes = 20
+
+	// 正常的表达式可以使用
+	code, _ := c.GenEleCode(newEle(`{{ user.name + ' (' + (user.age > 18 ? 'adult' : 'minor') + ')' }}`))
+	if !strings.Contains(code, `scope.Get("user", "name")`) {
+		t.Fatalf("code should read user.name: %s", code)
+	}
+
+	for _, text := range []string{
+		"{{ " + strings.Repeat("(", 20) + "a" + strings.Repeat(")", 20) + " }}",
+		"{{ " + strings.Repeat("[", 9) + strings.Repeat("]", 9) + " }}",
+		"{{ [a" + strings.Repeat(", a", 20) + "] }}",
+	} {
+		func() {
+			defer func() {
+				err := recover()
+				pe, ok := err.(*ParseError)
+				if !ok || pe.Category != CategoryComplexity {
+					t.Fatalf("%s: want complexity error, but: %v", text, err)
+				}
+			}()
+			c.GenEleCode(newEle(text))
+		}()
+	}
+
+	// 不限制时可以使用任何表达式
+	NewCompiler().GenEleCode(newEle("{{ a" + strings.Repeat(" + a", 20) + " }}"))
+}
+
+func TestMaxExprComplexityInRawText(t *testing.T) {
+	c := NewCompiler()
+	c.MaxExprNodes = 20
+
+	defer func() {
+		err, ok := recover().(*ParseError)
+		if !ok || err.Category != CategoryComplexity {
+			t.Fatalf("err = %v; want complexity error", err)
+		}
+	}()
+	c.GenEleCode(VueElementParser{}.Parse(&parser.Element{
+		NodeType: parser.ElementNode,
+		TagName:  "style",
+		Children: []*parser.Element{
+			{NodeType: parser.TextNode, Text: ".a { width: {{ a" + strings.Repeat(" + a", 20) + " }}px }"},
+		},
+	}))
+}
+
+func TestCodeComponent(t *testing.T) {
+	c := NewCompiler()
+	c.AddCodeComponent("code-card")
+
+	for _, tag := range []string{"code-card", "CodeCard"} {
+		code, _ := c.GenEleCode(VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  tag,
+		}))
+		if !strings.HasPrefix(code, `r.Render("codeCard", w, &Options{`) {
+			t.Fatalf("%s: code should dispatch to code component, code: %s", tag, code)
+		}
+	}
+}
+
+func TestTrimMarkers(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"a {{- x }}", `w.WriteString("a"+interfaceToStr(scope.Get("x"), true))`},
+		{"{{ x -}} b", `w.WriteString(interfaceToStr(scope.Get("x"), true)+"b")`},
+		{"a\n  {{- x -}}\n  b", `w.WriteString("a"+interfaceToStr(scope.Get("x"), true)+"b")`},
+		// 没有空白的-是表达式的一部分
+		{"a {{-1}}", `w.WriteString("a -1")`},
+		{"a {{ x }} b", `w.WriteString("a "+interfaceToStr(scope.Get("x"), true)+" b")`},
+	}
+
+	for _, c := range cases {
+		code, _ := NewCompiler().GenEleCode(&VueElement{NodeType: parser.TextNode, Text: c.text})
+		if code != c.want {
+			t.Fatalf("%q: code = %s; want: %s", c.text, code, c.want)
+		}
+	}
+}
+
+func TestBuildIf(t *testing.T) {
+	newEle := func() *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "div",
+			Children: []*parser.Element{
+				{NodeType: parser.ElementNode, TagName: "amp-img", Attrs: []html.Attribute{{Key: "v-build-if", Val: "amp"}}},
+				{NodeType: parser.ElementNode, TagName: "img", Attrs: []html.Attribute{{Key: "v-build-if", Val: "!amp"}}},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	code, _ := c.GenEleCode(newEle())
+	if !strings.Contains(code, "<img") || strings.Contains(code, "amp-img") || strings.Contains(code, "v-build-if") {
+		t.Fatalf("code should contain img only, code: %s", code)
+	}
+
+	c.BuildFlags = map[string]bool{"amp": true}
+	code, _ = c.GenEleCode(newEle())
+	if !strings.Contains(code, "<amp-img") || strings.Contains(code, "<img") {
+		t.Fatalf("code should contain amp-img only, code: %s", code)
+	}
+}
+
+func TestSanitizeURL(t *testing.T) {
+	newEle := func() *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "a",
+			Attrs: []html.Attribute{
+				{Key: ":href", Val: "url"},
+				{Key: ":title", Val: "url"},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	code, _ := c.GenEleCode(newEle())
+	if strings.Contains(code, "$sanitizeURL") {
+		t.Fatalf("url should not be sanitized by default, code: %s", code)
+	}
+
+	c.SanitizeURL = true
+	code, _ = c.GenEleCode(newEle())
+	if want := `"href": interfaceToFunc(scope.Get("$sanitizeURL"))(r, options, scope.Get("url"))`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+	if want := `"title": scope.Get("url")`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+}
+
+func TestCanonicalAttrs(t *testing.T) {
+	newEle := func(attrs ...html.Attribute) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "a",
+			Attrs:    attrs,
+		})
+	}
+	a := []html.Attribute{{Key: "title", Val: "t"}, {Key: "id", Val: "x"}, {Key: "class", Val: "c"}, {Key: "href", Val: "/"}}
+	b := []html.Attribute{{Key: "href", Val: "/"}, {Key: "class", Val: "c"}, {Key: "id", Val: "x"}, {Key: "title", Val: "t"}}
+
+	c := NewCompiler()
+	codeA, _ := c.GenEleCode(newEle(a...))
+	codeB, _ := c.GenEleCode(newEle(b...))
+	if codeA == codeB {
+		t.Fatalf("attrs should be in source order by default, code: %s", codeA)
+	}
+
+	c.CanonicalAttrs = true
+	codeA, _ = c.GenEleCode(newEle(a...))
+	codeB, _ = c.GenEleCode(newEle(b...))
+	if codeA != codeB {
+		t.Fatalf("code should be same, code: %s and %s", codeA, codeB)
+	}
+	if want := `" id=\"x\" href=\"/\" title=\"t\""`; !strings.Contains(codeA, want) {
+		t.Fatalf("code should contain %s, code: %s", want, codeA)
+	}
+
+	// 动态属性在运行时排序
+	codeA, _ = c.GenEleCode(newEle(html.Attribute{Key: ":title", Val: "t"}, html.Attribute{Key: "href", Val: "/"}))
+	if !strings.Contains(codeA, "mixinAttrCanonical(nil") {
+		t.Fatalf("code should contain mixinAttrCanonical, code: %s", codeA)
+	}
+}
+
+func TestVIfAssignment(t *testing.T) {
+	defer func() {
+		err := recover()
+		if err == nil {
+			t.Fatal("want panic for assignment in v-if")
+		}
+		if want := `assignment is not allowed in expression "x = 1", did you mean "=="?`; fmt.Sprint(err) != want {
+			t.Fatalf("err = %v; want: %s", err, want)
+		}
+	}()
+
+	NewCompiler().GenEleCode(VueElementParser{}.Parse(&parser.Element{
+		NodeType: parser.ElementNode,
+		TagName:  "p",
+		Attrs: []html.Attribute{
+			{Key: "v-if", Val: "x = 1"},
+		},
+	}))
+}
+
+// 保留的注释原样输出, 其中的{{}}不会被计算
+func TestKeepComments(t *testing.T) {
+	src := MapSource{"page.vue": "<template><div><!-- {{ secret }} \"x\" --><p>{{ name }}</p></div></template>"}
+
+	c := NewCompiler()
+	c.Source = src
+	c.KeepComments = true
+	ve, err := c.parseVue("page.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := c.GenEleCode(ve)
+	if !strings.Contains(code, `<!-- {{ secret }} \"x\" -->`) {
+		t.Fatalf("comment should be kept verbatim, code: %s", code)
+	}
+	if strings.Contains(code, `"secret"`) {
+		t.Fatalf("secret should not be evaluated, code: %s", code)
+	}
+
+	// 默认去掉注释
+	c = NewCompiler()
+	c.Source = src
+	ve, err = c.parseVue("page.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ = c.GenEleCode(ve)
+	if strings.Contains(code, "<!--") {
+		t.Fatalf("comment should be removed, code: %s", code)
+	}
+}
+
+// XHTML模式下bool属性输出为disabled="disabled", html模式下输出为disabled
+func TestXHTMLBoolAttrs(t *testing.T) {
+	newEle := func(attrs ...html.Attribute) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "input",
+			Attrs:    attrs,
+		})
+	}
+	static := []html.Attribute{{Key: "disabled"}, {Key: "value"}}
+
+	c := NewCompiler()
+	code, _ := c.GenEleCode(newEle(static...))
+	if want := `" disabled value"`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+	code, _ = c.GenEleCode(newEle(html.Attribute{Key: ":disabled", Val: "x"}))
+	if !strings.Contains(code, "mixinAttr(nil") {
+		t.Fatalf("code should contain mixinAttr, code: %s", code)
+	}
+
+	c.XHTML = true
+	code, _ = c.GenEleCode(newEle(static...))
+	// 只有bool属性会被转换
+	if want := `" disabled=\"disabled\" value"`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+	code, _ = c.GenEleCode(newEle(html.Attribute{Key: ":disabled", Val: "x"}))
+	if !strings.Contains(code, "mixinAttrXHTML(nil") {
+		t.Fatalf("code should contain mixinAttrXHTML, code: %s", code)
+	}
+}
+
+func TestExtractStylesCode(t *testing.T) {
+	newEle := func(attrs ...html.Attribute) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "p",
+			Attrs:    attrs,
+		})
+	}
+	styled := []html.Attribute{{Key: "class", Val: "a"}, {Key: "style", Val: "color: red"}}
+
+	c := NewCompiler()
+	code, _ := c.GenEleCode(newEle(styled...))
+	if want := `style=\"color: red; \"`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+
+	c.ExtractStyles = true
+	code, _ = c.GenEleCode(newEle(styled...))
+	if want := `r.extractStyle(nil, []string{"a"}, nil, map[string]string{"color": "red",}, nil)`; !strings.Contains(code, want) || strings.Contains(code, "style=") {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+	// 没有style的节点不受影响
+	code, _ = c.GenEleCode(newEle(html.Attribute{Key: "class", Val: "a"}))
+	if want := `class=\"a\"`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s, code: %s", want, code)
+	}
+}
+
+// script/style中的文本不是html, 不编码实体
+func TestRawTextNotEscaped(t *testing.T) {
+	newEle := func(tag, text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  tag,
+			Children: []*parser.Element{
+				{NodeType: parser.TextNode, Text: text},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	code, _ := c.GenEleCode(newEle("script", "if (a < b && c) {}"))
+	if want := `if (a < b && c) {}`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s: %s", want, code)
+	}
+	code, _ = c.GenEleCode(newEle("style", "p > a {}"))
+	if want := `p > a {}`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s: %s", want, code)
+	}
+
+	// 其他节点中的文本需要编码
+	code, _ = c.GenEleCode(newEle("p", "a < b && c"))
+	if want := `a &lt; b &amp;&amp; c`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s: %s", want, code)
+	}
+}
+
+// script/style中的插值同样检查没有闭合的{{, 但不受EmptyBool影响
+func TestRawTextInterpolation(t *testing.T) {
+	newEle := func(text string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "script",
+			Children: []*parser.Element{
+				{NodeType: parser.TextNode, Text: text},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.EmptyBool = true
+	code, _ := c.GenEleCode(newEle("var on = {{ on }};"))
+	if want := `"var on = "+interfaceToStr(scope.Get("on"), true)+";"`; !strings.Contains(code, want) {
+		t.Fatalf("code should contain %s: %s", want, code)
+	}
+
+	defer func() {
+		err, ok := recover().(*ParseError)
+		if !ok || err.Category != CategoryInterpolation {
+			t.Fatalf("err = %v; want an interpolation error", err)
+		}
+	}()
+	c.GenEleCode(newEle("var o = {{ a;"))
+}
+
+// :foo.attr的表达式同样受AllowedFuncs与表达式复杂度的限制
+func TestAttrPropsChecked(t *testing.T) {
+	newEle := func(val string) *VueElement {
+		return VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "div",
+			Attrs: []html.Attribute{
+				{Key: ":foo.attr", Val: val},
+			},
+		})
+	}
+
+	c := NewCompiler()
+	c.AllowedFuncs = map[string]bool{}
+	c.MaxExprDepth = 4
+	for _, tc := range []struct {
+		val  string
+		want ErrorCategory
+	}{
+		{"exec()", CategoryForbiddenFunc},
+		{strings.Repeat("(", 10) + "a" + strings.Repeat(")", 10), CategoryComplexity},
+	} {
+		func() {
+			defer func() {
+				pe, ok := recover().(*ParseError)
+				if !ok || pe.Category != tc.want {
+					t.Fatalf("%s: want %s error, but: %v", tc.val, tc.want, pe)
+				}
+			}()
+			c.GenEleCode(newEle(tc.val))
+		}()
+	}
+}
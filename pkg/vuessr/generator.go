@@ -12,30 +12,86 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 func genComponentRenderFunc(c *Compiler, pkgName, name string, file string, srcHash string) []byte {
-	ve, err := ParseVue(file)
-	code := `""`
+	ve, err := c.parseComponent(file)
 	if err != nil {
 		log.Warningf("parseVue err: %v, file: %v", err, file)
-	} else {
+	}
+	return genComponentCode(c, pkgName, name, ve, srcHash)
+}
+
+// 编译单个组件, 和genComponentRenderFunc不同的是模板错误会作为error返回而不是panic或只打印日志,
+// 出错时返回渲染空内容的方法, 保证其他组件调用它时生成的包仍然能通过编译.
+func compileComponent(c *Compiler, pkgName, name string, file string, srcHash string) (code []byte, err error) {
+	ve, err := c.parseComponent(file)
+	if err != nil {
+		return genComponentCode(c, pkgName, name, nil, ""), err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			pe := toParseError(e, 0)
+			pe.File = file
+			err = pe
+			// 出错的组件不记录src_hash, 下次编译时总会重新编译
+			code = genComponentCode(c, pkgName, name, nil, "")
+		}
+	}()
+
+	code = genComponentCode(c, pkgName, name, ve, srcHash)
+	return
+}
+
+func (c *Compiler) parseComponent(file string) (*VueElement, error) {
+	c.file = filepath.ToSlash(filepath.Clean(file))
+	c.memoCount = 0
+	return c.parseVue(file)
+}
+
+// v-memo缓存的key中的文件名, 使用相对于src目录的路径, 使在不同的工作目录中编译时生成的代码相同
+func (c *Compiler) memoFile() string {
+	if c.src == "" {
+		return c.file
+	}
+	rel, err := filepath.Rel(c.src, filepath.FromSlash(c.file))
+	if err != nil {
+		return c.file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// 生成组件的渲染方法, ve为nil时生成的方法不渲染任何内容
+func genComponentCode(c *Compiler, pkgName, name string, ve *VueElement, srcHash string) []byte {
+	code := `""`
+	funcComment := ""
+	if ve != nil {
 		code, _ = c.GenEleCode(ve)
 		code = minifyCode(code)
+		if c.SourceMap {
+			funcComment = fmt.Sprintf("// %s:%d\n", c.file, ve.Line)
+		}
 	}
 
 	f := []byte(fmt.Sprintf("// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr\n// src_hash:%s\n\n"+
 		"package %s\n\n"+
 		"import (\"strings\")\ntype _ strings.Builder\n"+
-		"func xx_%s(r *Render, w Writer, options *Options){\n"+
+		"%sfunc xx_%s(r *Render, w Writer, options *Options){\n"+
+		"if r.canceled() || !r.enter(%q, options) {\nreturn\n}\n"+
+		"w, hookDone := r.hookComponent(%q, w)\n"+
 		"%s:= extendScope(r.Global, options.Props.data)\n"+
 		"_ = %s\n"+
 		"%s\n"+
+		"hookDone()\n"+
 		"return"+
-		"}", srcHash, pkgName, name, ScopeKey, ScopeKey, code))
+		"}", srcHash, pkgName, funcComment, name, name, name, c.ScopeKey, c.ScopeKey, code))
 	f2, err := format.Source(f)
 	if err != nil {
 		log.Errorf("format.Source [%s] err:%+v, src:%s", name, err, f)
@@ -107,20 +163,44 @@ func sheXing2TuoFeng(src string) (outStr string) {
 	return string(out)
 }
 
-func genCreator(components map[string]string, pkgName string) []byte {
+func genCreator(c *Compiler, pkgName string) []byte {
 	m := map[string]string{}
-	for tagName, comName := range components {
+	for tagName, comName := range c.Components {
 		m[tagName] = fmt.Sprintf(`xx_%s`, comName)
 	}
 
+	// 如果修改了void元素等设置, 运行时也需要使用同样的设置
+	optionCode := ""
+	if !reflect.DeepEqual(c.VoidElements, voidElements) {
+		v := map[string]string{}
+		for tagName, isVoid := range c.VoidElements {
+			v[tagName] = strconv.FormatBool(isVoid)
+		}
+		optionCode = fmt.Sprintf("r.VoidElements = %s\n", mapGoCodeToCode(v, "bool", true))
+	}
+
+	if c.CanonicalAttrs {
+		optionCode += "r.CanonicalAttrs = true\n"
+	}
+	if c.ExtractStyles {
+		optionCode += "r.ExtractStyles = true\n"
+	}
+	if c.XHTML {
+		optionCode += "r.XHTML = true\n"
+	}
+	if c.SanitizeURL {
+		optionCode += "r.SanitizeURL = true\n"
+	}
+
 	f := []byte(fmt.Sprintf("// Code generated by go-vue-ssr: https://github.com/zbysir/go-vue-ssr\n\n"+
 		"package %s\n\n"+
 		"func NewRenderCreator() *RenderCreator{"+
 		"r:=newRenderCreator()\n"+
 		"r.Components = %s\n"+
+		"%s"+
 		"return r"+
 		"}",
-		pkgName, mapGoCodeToCode(m, "ComponentFunc", true)))
+		pkgName, mapGoCodeToCode(m, "ComponentFunc", true), optionCode))
 
 	formatted, err := format.Source(f)
 	if err != nil {
@@ -131,19 +211,260 @@ func genCreator(components map[string]string, pkgName string) []byte {
 	return formatted
 }
 
+// DumpGoCode 返回单个模板生成的(已格式化的)go代码, 不会写入文件, 用于排查模板的渲染问题.
+// 注意: 只有模板自身会被注册为组件, 模板中使用的其他组件会被当作普通的html节点.
+func DumpGoCode(filename string) (code string, err error) {
+	_, err = ParseVue(filename)
+	if err != nil {
+		return
+	}
+
+	// 编译错误会panic
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("compile %s err: %v", filename, e)
+		}
+	}()
+
+	_, fileName := filepath.Split(filename)
+	name := componentName(strings.TrimSuffix(fileName, ".vue"))
+
+	c := NewCompiler()
+	c.AddComponent(name)
+
+	bs := genComponentRenderFunc(c, "main", name, filename, "")
+	// genComponentRenderFunc在格式化失败时会返回未格式化的代码
+	_, err = format.Source(bs)
+	if err != nil {
+		return
+	}
+
+	code = string(bs)
+	return
+}
+
 // 组件名字, 驼峰
 func componentName(src string) string {
 	return sheXing2TuoFeng(src)
 }
 
+// 编译单个模板时的错误
+type CompileError struct {
+	File     string
+	Line     int // 出错节点的行号, 为0时表示未知(如读取文件失败)
+	Msg      string
+	Category ErrorCategory
+	Err      error // 原始的错误, 模板错误时为*ParseError
+}
+
+func newCompileError(file string, err error) CompileError {
+	e := CompileError{File: file, Msg: err.Error(), Category: CategoryRead, Err: err}
+	if pe, ok := err.(*ParseError); ok {
+		e.Line = pe.Line
+		e.Msg = pe.Msg
+		e.Category = pe.Category
+	}
+	return e
+}
+
+func (e CompileError) Error() string {
+	// ParseError中已经包含了文件名
+	if pe, ok := e.Err.(*ParseError); ok && pe.File != "" {
+		return pe.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
+}
+
+// 批量编译(LoadDir/GenAllFile)时所有模板的错误, 一个模板出错不会影响其他模板的编译
+type CompileErrors []CompileError
+
+func (e CompileErrors) Error() string {
+	ss := make([]string, len(e))
+	for i, v := range e {
+		ss[i] = v.Error()
+	}
+	return fmt.Sprintf("%d template(s) failed to compile:\n%s", len(e), strings.Join(ss, "\n"))
+}
+
+func (e CompileErrors) has(file string) bool {
+	for _, v := range e {
+		if v.File == file {
+			return true
+		}
+	}
+	return false
+}
+
 type VueFile struct {
 	ComponentName string // xText
 	Path          string
-	Filename      string // x-text.vue
+	Filename      string      // x-text.vue
+	Element       *VueElement // 解析后的模板, 只有LoadDir会填充
+}
+
+// 加载文件夹(包括子文件夹)下所有的模板文件(扩展名见Compiler.Ext), 见LoadTemplates.
+func (c *Compiler) LoadDir(dir string) (err error) {
+	vueFiles, err := walkDir(dir, c.ext())
+	if err != nil {
+		return
+	}
+
+	return c.LoadTemplates(vueFiles...)
+}
+
+// 加载模板, 将文件名(去掉扩展名)注册为组件并解析模板, 解析后的模板存放在c.Files中.
+// 模板通过c.Source读取, 所以names不一定是文件系统中的路径.
+// 不同文件的组件名相同时(如a/foo-bar.vue与b/fooBar.vue)会返回错误.
+// 解析失败的模板不会中断加载, 所有失败会合并为CompileErrors返回, 其他模板仍然会被加载.
+func (c *Compiler) LoadTemplates(names ...string) (err error) {
+	var errs CompileErrors
+
+	for _, v := range names {
+		_, fileName := filepath.Split(v)
+		name := componentName(strings.TrimSuffix(fileName, c.ext()))
+
+		if old, ok := c.Files[name]; ok {
+			err = fmt.Errorf("component name conflict: %s, file: %s and %s", name, old.Path, v)
+			return
+		}
+
+		ve, e := c.parseVue(v)
+		if e != nil {
+			errs = append(errs, newCompileError(v, e))
+			continue
+		}
+
+		c.Files[name] = &VueFile{
+			ComponentName: name,
+			Path:          v,
+			Filename:      fileName,
+			Element:       ve,
+		}
+
+		c.AddComponent(name)
+	}
+
+	if len(errs) != 0 {
+		err = errs
+	}
+	return
+}
+
+// 从入口组件开始, 查找所有可达(被引用到)的组件, 返回的组件名包含入口组件本身.
+// 需要先调用LoadDir加载组件.
+// 注意: 动态组件<component :is="name">无法在编译期确定, 不会被计算在内.
+func (c *Compiler) Reachable(entry ...string) (names []string, err error) {
+	reached := map[string]bool{}
+
+	var walk func(e *VueElement)
+	walk = func(e *VueElement) {
+		tagName := e.TagName
+		if e.TagName == "component" {
+			// 静态的is: <component is="info">
+			for _, a := range e.Attrs {
+				if a.Key == "is" {
+					tagName = a.Val
+				}
+			}
+		}
+
+		if name, ok := c.component(tagName); ok && !reached[name] {
+			reached[name] = true
+			names = append(names, name)
+			if f, ok := c.Files[name]; ok {
+				walk(f.Element)
+			}
+		}
+
+		for _, ch := range e.Children {
+			walk(ch)
+		}
+	}
+
+	for _, name := range entry {
+		name = componentName(name)
+		f, ok := c.Files[name]
+		if !ok {
+			err = fmt.Errorf("entry component not found: %s", name)
+			return
+		}
+
+		if !reached[name] {
+			reached[name] = true
+			names = append(names, name)
+			walk(f.Element)
+		}
+	}
+
+	return
+}
+
+// 解析所有组件文件并放入c.Files中, 解析失败的文件会跳过并合并为CompileErrors返回
+func (c *Compiler) loadFiles(vs []VueFile) (err error) {
+	var errs CompileErrors
+	for _, v := range vs {
+		ve, e := c.parseVue(v.Path)
+		if e != nil {
+			errs = append(errs, newCompileError(v.Path, e))
+			continue
+		}
+		c.Files[v.ComponentName] = &VueFile{
+			ComponentName: v.ComponentName,
+			Path:          v.Path,
+			Filename:      v.Filename,
+			Element:       ve,
+		}
+	}
+	if len(errs) != 0 {
+		err = errs
+	}
+	return
+}
+
+// 只保留从entry可达的组件, 并从c.Components中删除不可达的组件
+func (c *Compiler) shake(vs []VueFile, entry []string) (reachedVs []VueFile, err error) {
+	names, err := c.Reachable(entry...)
+	if err != nil {
+		return
+	}
+	reached := map[string]bool{}
+	for _, n := range names {
+		reached[n] = true
+	}
+
+	for _, v := range vs {
+		if reached[v.ComponentName] {
+			reachedVs = append(reachedVs, v)
+		}
+	}
+	for tagName, name := range c.Components {
+		if !reached[name] {
+			delete(c.Components, tagName)
+		}
+	}
+	c.reindexComponents()
+
+	return
 }
 
 // 生成并写入文件夹
 func GenAllFile(src, desc string, pkg string) (err error) {
+	return GenAllFileWithEntry(src, desc, pkg, nil)
+}
+
+// 生成并写入文件夹, 只会生成从entry组件可达的组件代码(tree-shaking), 如果entry为空则生成所有组件.
+func GenAllFileWithEntry(src, desc string, pkg string, entry []string) (err error) {
+	return NewCompiler().GenAllFile(src, desc, pkg, entry)
+}
+
+// 使用当前Compiler的设置(如CodeComponents)生成并写入文件夹, 见GenAllFileWithEntry
+// 注意: Components会根据src中的文件重新生成
+func (c *Compiler) GenAllFile(src, desc string, pkg string, entry []string) (err error) {
+	err = CheckScopeKey(c.ScopeKey)
+	if err != nil {
+		return
+	}
+
 	// 生成文件夹
 	err = os.MkdirAll(desc, os.ModePerm)
 	if err != nil {
@@ -168,17 +489,21 @@ func GenAllFile(src, desc string, pkg string) (err error) {
 	}
 
 	// 生成新的组件文件
-	vueFiles, err := walkDir(src, ".vue")
+	c.src = src
+	vueFiles, err := walkDir(src, c.ext())
 	if err != nil {
 		return
 	}
 
-	c := NewCompiler()
+	// 重新生成时(如watch)模板可能已经被删除或修改, 需要重新加载
+	c.Components = map[string]string{}
+	c.Files = map[string]*VueFile{}
+	c.componentIndex = nil
 
 	var vs []VueFile
 	for _, v := range vueFiles {
 		_, fileName := filepath.Split(v)
-		name := componentName(strings.TrimSuffix(fileName, ".vue"))
+		name := componentName(strings.TrimSuffix(fileName, c.ext()))
 
 		vs = append(vs, VueFile{
 			ComponentName: name,
@@ -190,13 +515,33 @@ func GenAllFile(src, desc string, pkg string) (err error) {
 		c.AddComponent(name)
 	}
 
+	// tree-shaking与内联都需要先解析所有组件
+	// 模板错误不会中断编译, 所有错误会在最后合并返回
+	var errs CompileErrors
+	if len(entry) != 0 || c.InlineSize > 0 {
+		err = c.loadFiles(vs)
+		if es, ok := err.(CompileErrors); ok {
+			errs = append(errs, es...)
+			err = nil
+		}
+		if err != nil {
+			return
+		}
+	}
+	if len(entry) != 0 {
+		vs, err = c.shake(vs, entry)
+		if err != nil {
+			return
+		}
+	}
+
 	_, pkgName := filepath.Split(desc)
 	if pkg != "" {
 		pkgName = pkg
 	}
 
 	// 生成new代码
-	code := genCreator(c.Components, pkgName)
+	code := genCreator(c, pkgName)
 	err = ioutil.WriteFile(desc+string(os.PathSeparator)+"creator.go", code, 0666)
 	if err != nil {
 		return
@@ -204,12 +549,13 @@ func GenAllFile(src, desc string, pkg string) (err error) {
 
 	willDelOld := oldVs
 
+	salt := c.hashSalt()
 	// 生成vue组件代码
 	for _, v := range vs {
 		vuePath := v.Path
 		// 读取文件是否改变
 		// 只有改变过才会再次编译，优化性能
-		srcHash := fileMd5(vuePath, version.Version)
+		srcHash := c.fileMd5(vuePath, salt)
 
 		codePath := desc + string(os.PathSeparator) + v.ComponentName + ".vue.go"
 
@@ -227,7 +573,10 @@ func GenAllFile(src, desc string, pkg string) (err error) {
 			}
 		}
 
-		newCode := genComponentRenderFunc(c, pkgName, v.ComponentName, v.Path, srcHash)
+		newCode, e := compileComponent(c, pkgName, v.ComponentName, v.Path, srcHash)
+		if e != nil && !errs.has(v.Path) {
+			errs = append(errs, newCompileError(v.Path, e))
+		}
 
 		if _, ok := oldVs[v.ComponentName]; ok {
 			// 如果有新代码则不删除老代码, 要么覆盖, 要么不动(新老代码一样)
@@ -273,10 +622,18 @@ func GenAllFile(src, desc string, pkg string) (err error) {
 		return
 	}
 
+	if len(errs) != 0 {
+		err = errs
+	}
 	return
 }
 
-func GenAllFileWithWatch(ctx context.Context, src, desc string, pkg string) (err error) {
+func GenAllFileWithWatch(ctx context.Context, src, desc string, pkg string, entry ...string) (err error) {
+	return NewCompiler().GenAllFileWithWatch(ctx, src, desc, pkg, entry...)
+}
+
+// 使用当前Compiler的设置监听并生成, 见GenAllFileWithWatch
+func (c *Compiler) GenAllFileWithWatch(ctx context.Context, src, desc string, pkg string, entry ...string) (err error) {
 	log.Infof("watching dir and subdirectories: %s", src)
 
 	w := watcher.New()
@@ -289,7 +646,7 @@ func GenAllFileWithWatch(ctx context.Context, src, desc string, pkg string) (err
 	w.SetMaxEvents(1)
 	// Only files that match the regular expression during file listings
 	// will be watched.
-	r := regexp.MustCompile(".vue$")
+	r := regexp.MustCompile(regexp.QuoteMeta(c.ext()) + "$")
 	w.AddFilterHook(watcher.RegexFilterHook(r, false))
 
 	go w.Start(400 * time.Millisecond)
@@ -304,7 +661,12 @@ func GenAllFileWithWatch(ctx context.Context, src, desc string, pkg string) (err
 		case e, ok := <-w.Event:
 			if ok {
 				log.Infof("file changed: %v", e.Path)
-				err = GenAllFile(src, desc, pkg)
+				err = c.GenAllFile(src, desc, pkg, entry)
+				if es, ok := err.(CompileErrors); ok {
+					// 模板错误不中断监听, 修改模板后会重新编译
+					log.Errorf("%v", es)
+					continue
+				}
 				if err != nil {
 					return
 				}
@@ -339,8 +701,114 @@ func walkDir(dirPth string, suffix string) (files []string, err error) {
 	return
 }
 
-func fileMd5(filePath string, salt string) string {
-	oldCode, err := ioutil.ReadFile(filePath)
+// 用于判断生成的代码是否需要更新, 除了源文件之外, 生成的代码还受版本与Compiler设置的影响
+func (c *Compiler) hashSalt() string {
+	salt := version.Version
+	if len(c.CodeComponents) != 0 {
+		salt += strings.Join(getSortedKey(c.CodeComponents), ",")
+	}
+	var flags []string
+	for k, on := range c.BuildFlags {
+		if on {
+			flags = append(flags, k)
+		}
+	}
+	sort.Strings(flags)
+	for _, k := range flags {
+		salt += "+" + k
+	}
+	if c.SanitizeURL {
+		salt += "+sanitize-url"
+	}
+	if c.StrictAttrs {
+		salt += "+strict-attrs"
+	}
+	if c.KeepComments {
+		salt += "+keep-comments"
+	}
+	if c.CacheExpr {
+		salt += "+cache-expr"
+	}
+	if c.EmptyBool {
+		salt += "+empty-bool"
+	}
+	if c.TrimInterpolation {
+		salt += "+trim-interpolation"
+	}
+	if c.ExtractStyles {
+		salt += "+extract-styles"
+	}
+	if c.ScopeKey != ScopeKey {
+		salt += "+scope-key=" + c.ScopeKey
+	}
+	if c.XHTML {
+		salt += "+xhtml"
+	}
+	if c.Whitespace != WhitespaceRemove {
+		salt += fmt.Sprintf("+whitespace=%d", c.Whitespace)
+	}
+	if c.CanonicalAttrs {
+		salt += "+canonical-attrs"
+	}
+	salt += saltSet("allowed-funcs", c.AllowedFuncs)
+	salt += saltSet("allowed-vars", c.AllowedVars)
+	if c.MaxExprDepth != 0 || c.MaxExprNodes != 0 {
+		salt += fmt.Sprintf("+max-expr=%d,%d", c.MaxExprDepth, c.MaxExprNodes)
+	}
+	if c.SourceMap {
+		salt += "+source-map"
+	}
+	// 自定义的元素集合, 默认值不计入
+	if !reflect.DeepEqual(c.VoidElements, voidElements) {
+		salt += saltSet("void-elements", c.VoidElements)
+	}
+	if !reflect.DeepEqual(c.BlockElements, blockElements) {
+		salt += saltSet("block-elements", c.BlockElements)
+	}
+	if !reflect.DeepEqual(c.RawTextElements, rawTextElements) {
+		salt += saltSet("raw-text-elements", c.RawTextElements)
+	}
+	salt += saltSet("client-directives", c.ClientDirectives)
+	if c.Hydrate {
+		salt += "+hydrate"
+	}
+	if c.ValidateKey {
+		salt += "+validate-key"
+	}
+	if c.InlineSize > 0 {
+		// 被内联的组件改变时, 使用它的组件也需要重新生成
+		salt += fmt.Sprintf("+inline=%d", c.InlineSize)
+		names := make([]string, 0, len(c.Files))
+		for name := range c.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if c.inlinable(name) {
+				salt += "+" + c.fileMd5(c.Files[name].Path, "")
+			}
+		}
+	}
+	return salt
+}
+
+// 集合类型的设置在hashSalt中的部分, 为nil时返回空(不限制与空集合不同)
+func saltSet(name string, m map[string]bool) string {
+	if m == nil {
+		return ""
+	}
+	var keys []string
+	for k, on := range m {
+		if on {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return "+" + name + "=" + strings.Join(keys, ",")
+}
+
+func (c *Compiler) fileMd5(filePath string, salt string) string {
+	oldCode, err := c.readTemplate(filePath)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return ""
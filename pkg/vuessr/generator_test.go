@@ -1,6 +1,14 @@
 package vuessr
 
 import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -18,7 +26,7 @@ func TestGenAllFile(t *testing.T) {
 func TestGenComponentRenderFunc(t *testing.T) {
 	app := NewCompiler()
 
-	code := genComponentRenderFunc(app, "gebera", "xx", `Z:\golang\go_path\src\github.com\zbysir\go-vue-ssr\internal\test\vue\svg.vue`,"")
+	code := genComponentRenderFunc(app, "gebera", "xx", `Z:\golang\go_path\src\github.com\zbysir\go-vue-ssr\internal\test\vue\svg.vue`, "")
 	t.Logf("%s", code)
 }
 
@@ -107,3 +115,632 @@ func shouldLookInterface(data interface{}, key string) (desc interface{}, exist
 
 	return
 }
+
+func TestLoadDir(t *testing.T) {
+	c := NewCompiler()
+	err := c.LoadDir("./test_src/load_dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"page", "infoCard", "footer"} {
+		f, ok := c.Files[name]
+		if !ok {
+			t.Fatalf("component %s not loaded", name)
+		}
+		if c.Components[tuoFeng2SheXing(name)] != name {
+			t.Fatalf("component %s not registered", name)
+		}
+
+		code := genComponentRenderFunc(c, "vuetpl", name, f.Path, "")
+		if _, err := format.Source(code); err != nil {
+			t.Fatalf("component %s compile err: %v, code: %s", name, err, code)
+		}
+	}
+
+	// info-card 在page中被识别为组件
+	code, _ := c.GenEleCode(c.Files["page"].Element)
+	if !strings.Contains(code, "xx_infoCard(r, w, ") {
+		t.Fatalf("info-card should be a component, code: %s", code)
+	}
+}
+
+func TestLoadDirConflict(t *testing.T) {
+	c := NewCompiler()
+	err := c.LoadDir("./test_src/load_dir_conflict")
+	if err == nil || !strings.Contains(err.Error(), "component name conflict: infoCard") {
+		t.Fatalf("want conflict err, but: %v", err)
+	}
+}
+
+// 解析失败的模板不会中断加载, 其他模板仍然会被加载
+func TestLoadDirCompileErrors(t *testing.T) {
+	c := NewCompiler()
+	err := c.LoadDir("./test_src/compile_errors")
+	es, ok := err.(CompileErrors)
+	if !ok || len(es) != 1 || filepath.Base(es[0].File) != "broken.vue" {
+		t.Fatalf("want broken.vue err, but: %v", err)
+	}
+
+	for _, name := range []string{"good", "other", "badExpr"} {
+		if _, ok := c.Files[name]; !ok {
+			t.Fatalf("component %s not loaded", name)
+		}
+	}
+	if _, ok := c.Files["broken"]; ok {
+		t.Fatalf("broken should not be loaded")
+	}
+}
+
+// 模板可以来自文件系统之外, 扩展名也可以修改
+func TestLoadTemplatesFromSource(t *testing.T) {
+	c := NewCompiler()
+	c.Ext = ".tpl"
+	c.Source = MapSource{
+		"tpl/page.tpl":      `<template><div><info-card :name="name"></info-card></div></template>`,
+		"tpl/info-card.tpl": `<template><span>{{name}}</span></template>`,
+	}
+	err := c.LoadTemplates("tpl/page.tpl", "tpl/info-card.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"page", "infoCard"} {
+		f, ok := c.Files[name]
+		if !ok {
+			t.Fatalf("component %s not loaded", name)
+		}
+
+		code := genComponentRenderFunc(c, "vuetpl", name, f.Path, "")
+		if _, err := format.Source(code); err != nil {
+			t.Fatalf("component %s compile err: %v, code: %s", name, err, code)
+		}
+	}
+
+	code, _ := c.GenEleCode(c.Files["page"].Element)
+	if !strings.Contains(code, "xx_infoCard(r, w, ") {
+		t.Fatalf("info-card should be a component, code: %s", code)
+	}
+
+	err = c.LoadTemplates("tpl/missing.tpl")
+	es, ok := err.(CompileErrors)
+	if !ok || len(es) != 1 || !os.IsNotExist(es[0].Err) {
+		t.Fatalf("want not exist err, but: %v", err)
+	}
+}
+
+func TestReachable(t *testing.T) {
+	c := NewCompiler()
+	err := c.LoadDir("./test_src/tree_shaking")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := c.Reachable("page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"page", "infoCard"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("names = %v; want: %v", names, want)
+	}
+}
+
+// 创建临时目录, 测试结束时调用clean删除
+func tempDir(t *testing.T) (dir string, clean func()) {
+	dir, err := ioutil.TempDir("", "vuessr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestGenAllFileWithEntry(t *testing.T) {
+	desc, clean := tempDir(t)
+	defer clean()
+	err := GenAllFileWithEntry("./test_src/tree_shaking", desc, "vuetpl", []string{"page"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{"page.vue.go", "infoCard.vue.go"} {
+		if _, err := os.Stat(filepath.Join(desc, f)); err != nil {
+			t.Fatalf("%s should be emitted: %v", f, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(desc, "orphan.vue.go")); !os.IsNotExist(err) {
+		t.Fatalf("orphan.vue.go should not be emitted")
+	}
+
+	creator, err := ioutil.ReadFile(filepath.Join(desc, "creator.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(creator), "xx_orphan") {
+		t.Fatalf("orphan should not be registered: %s", creator)
+	}
+}
+
+func TestGenComponentRenderFuncScopeKey(t *testing.T) {
+	c := NewCompiler()
+	c.ScopeKey = "data"
+	c.AddComponent("slot-child")
+
+	code := string(genComponentRenderFunc(c, "test", "List", "./test_src/scope_key/list.vue", ""))
+
+	// 生成的代码是合法的go代码
+	_, err := parser.ParseFile(token.NewFileSet(), "", code, 0)
+	if err != nil {
+		t.Fatalf("%v, code: %s", err, code)
+	}
+
+	if regexp.MustCompile(`\bscope\b`).MatchString(code) {
+		t.Fatalf("default scope key should not be used, code: %s", code)
+	}
+	for _, want := range []string{`data := extendScope(r.Global, options.Props.data)`, `data.Get("list")`, `data.Get("label")`, `data.Set("slotProps", props.Map())`, `data.Get("slotProps", "a")`, `Scope: data`} {
+		if !strings.Contains(code, want) {
+			t.Fatalf("code should contain %q, code: %s", want, code)
+		}
+	}
+}
+
+func TestCheckScopeKey(t *testing.T) {
+	for _, key := range []string{ScopeKey, "data", "ctx", "_scope"} {
+		if err := CheckScopeKey(key); err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "_", "r", "w", "options", "props", "func", "range", "string", "len", "a-b", "1a", "Render", "interfaceToStr", "strings", "xx_page"} {
+		if err := CheckScopeKey(key); err == nil {
+			t.Fatalf("%q should be rejected", key)
+		}
+	}
+
+	desc, clean := tempDir(t)
+	defer clean()
+	c := NewCompiler()
+	c.ScopeKey = "options"
+	err := c.GenAllFile("./test_src/tree_shaking", desc, "vuetpl", nil)
+	if err == nil || err.Error() != `invalid scope key "options": it is used by the generated code` {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(desc, "creator.go")); !os.IsNotExist(err) {
+		t.Fatalf("nothing should be emitted with a bad scope key")
+	}
+}
+
+func TestGenComponentRenderFuncSourceMap(t *testing.T) {
+	c := NewCompiler()
+	c.SourceMap = true
+	c.AddComponent("info-card")
+
+	code := string(genComponentRenderFunc(c, "test", "Page", "./test_src/source_map/page.vue", ""))
+
+	for _, want := range []string{
+		"// test_src/source_map/page.vue:1\nfunc xx_Page(",
+		"// test_src/source_map/page.vue:4\n",
+		"// test_src/source_map/page.vue:5\n",
+	} {
+		if !strings.Contains(code, want) {
+			t.Fatalf("code should contain %q, code: %s", want, code)
+		}
+	}
+
+	// 默认不生成注释
+	code = string(genComponentRenderFunc(NewCompiler(), "test", "Page", "./test_src/source_map/page.vue", ""))
+	if strings.Contains(code, "page.vue:") {
+		t.Fatalf("code should not contain source map, code: %s", code)
+	}
+}
+
+// 具名插槽按照名字排序生成, 多次生成的代码相同
+func TestGenComponentRenderFuncSlotOrder(t *testing.T) {
+	gen := func() string {
+		c := NewCompiler()
+		c.AddComponent("named-slots-child")
+		return string(genComponentRenderFunc(c, "test", "Page", "./test_src/named_slots/page.vue", ""))
+	}
+
+	code := gen()
+	for i := 0; i < 10; i++ {
+		if gen() != code {
+			t.Fatalf("code should be stable")
+		}
+	}
+
+	last := -1
+	for _, name := range []string{`"body"`, `"default"`, `"footer"`, `"header"`} {
+		i := strings.Index(code, name+": func")
+		if i == -1 || i < last {
+			t.Fatalf("slot %s should be sorted, code: %s", name, code)
+		}
+		last = i
+	}
+}
+
+func TestDumpGoCode(t *testing.T) {
+	code, err := DumpGoCode("./test_src/source_map/page.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"func xx_page(r *Render, w Writer, options *Options) {",
+		`if interfaceToBool(scope.Get("show")) {`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Fatalf("code should contain %q, code: %s", want, code)
+		}
+	}
+
+	_, err = DumpGoCode("./test_src/source_map/not_exist.vue")
+	if err == nil {
+		t.Fatal("want err for not exist file")
+	}
+}
+
+// 大小写无关的查找使用注册时建立的索引, 小写相同时使用排序最小的key
+func TestComponentFoldIndex(t *testing.T) {
+	c := NewCompiler()
+	c.AddComponent("foobar")
+	c.AddComponent("fooBar")
+	for _, tag := range []string{"FOOBAR", "FooBar"} {
+		if name, ok := c.component(tag); !ok || name != "fooBar" {
+			t.Fatalf("%s: name = %s, %v; want: fooBar", tag, name, ok)
+		}
+	}
+
+	delete(c.Components, "fooBar")
+	delete(c.Components, "foo-bar")
+	c.reindexComponents()
+	if name, ok := c.component("FOOBAR"); !ok || name != "foobar" {
+		t.Fatalf("name = %s, %v; want: foobar", name, ok)
+	}
+	delete(c.Components, "foobar")
+	c.reindexComponents()
+	if name, ok := c.component("FOOBAR"); ok {
+		t.Fatalf("removed component should not be found: %s", name)
+	}
+}
+
+func TestGenComponentRenderFuncComponentName(t *testing.T) {
+	for _, name := range []string{"Foo", "foo"} {
+		c := NewCompiler()
+		c.AddComponent(name)
+		code := string(genComponentRenderFunc(c, "test", "Page", "./test_src/component_name/page.vue", ""))
+
+		call := "xx_" + name + "(r, w, "
+		if n := strings.Count(code, call); n != 4 {
+			t.Fatalf("register %s: component should be called 4 times, got %d, code: %s", name, n, code)
+		}
+	}
+}
+
+// 每个组件生成一个文件, 与运行时代码(builtin.go)和creator.go在同一个包下
+// 重新生成时(如watch)不会保留已经删除的模板
+func TestGenAllFileReload(t *testing.T) {
+	src, clean := tempDir(t)
+	defer clean()
+	desc, cleanDesc := tempDir(t)
+	defer cleanDesc()
+
+	for _, name := range []string{"page.vue", "infoCard.vue"} {
+		err := ioutil.WriteFile(filepath.Join(src, name), []byte("<template><div>"+name+"</div></template>"), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := NewCompiler()
+	c.InlineSize = 10
+	if err := c.GenAllFile(src, desc, "vuetpl", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Files["infoCard"]; !ok {
+		t.Fatalf("infoCard should be loaded")
+	}
+
+	if err := os.Remove(filepath.Join(src, "infoCard.vue")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.GenAllFile(src, desc, "vuetpl", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Files["infoCard"]; ok {
+		t.Fatalf("removed infoCard should not be kept")
+	}
+	if _, err := os.Stat(filepath.Join(desc, "infoCard.vue.go")); !os.IsNotExist(err) {
+		t.Fatalf("infoCard.vue.go should be removed")
+	}
+}
+
+// 修改了AllowedFuncs/AllowedVars后, 没有改变的模板也需要重新编译与检查
+func TestGenAllFileAllowedFuncsSalt(t *testing.T) {
+	src, clean := tempDir(t)
+	defer clean()
+	desc, cleanDesc := tempDir(t)
+	defer cleanDesc()
+
+	err := ioutil.WriteFile(filepath.Join(src, "page.vue"), []byte("<template><div>{{ evil(1) }}</div></template>"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewCompiler().GenAllFile(src, desc, "vuetpl", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCompiler()
+	c.AllowedFuncs = map[string]bool{}
+	if err := c.GenAllFile(src, desc, "vuetpl", nil); err == nil {
+		t.Fatalf("evil should not be allowed")
+	}
+}
+
+// 修改了MaxExprDepth/MaxExprNodes后, 没有改变的模板也需要重新检查
+func TestGenAllFileMaxExprSalt(t *testing.T) {
+	src, clean := tempDir(t)
+	defer clean()
+	desc, cleanDesc := tempDir(t)
+	defer cleanDesc()
+
+	err := ioutil.WriteFile(filepath.Join(src, "page.vue"), []byte("<template><div>{{ a + b * c }}</div></template>"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewCompiler().GenAllFile(src, desc, "vuetpl", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, set := range []func(c *Compiler){
+		func(c *Compiler) { c.MaxExprNodes = 1 },
+		func(c *Compiler) { c.MaxExprDepth = 1 },
+	} {
+		c := NewCompiler()
+		set(c)
+		if err := c.GenAllFile(src, desc, "vuetpl", nil); err == nil {
+			t.Fatalf("expression should exceed the limit")
+		}
+	}
+}
+
+// 影响生成代码的设置都需要改变hashSalt, 否则GenAllFile会保留旧的代码
+func TestHashSaltOptions(t *testing.T) {
+	base := NewCompiler().hashSalt()
+	for name, set := range map[string]func(c *Compiler){
+		"SourceMap":        func(c *Compiler) { c.SourceMap = true },
+		"VoidElements":     func(c *Compiler) { c.VoidElements["icon"] = true },
+		"BlockElements":    func(c *Compiler) { delete(c.BlockElements, "p") },
+		"RawTextElements":  func(c *Compiler) { c.RawTextElements["code"] = true },
+		"ClientDirectives": func(c *Compiler) { c.ClientDirectives = map[string]bool{"v-focus": true} },
+		"Hydrate":          func(c *Compiler) { c.Hydrate = true },
+		"ValidateKey":      func(c *Compiler) { c.ValidateKey = true },
+	} {
+		c := NewCompiler()
+		set(c)
+		if c.hashSalt() == base {
+			t.Fatalf("%s should change the salt", name)
+		}
+	}
+}
+
+// v-memo的key不应该依赖编译时的工作目录
+func TestGenAllFileMemoKey(t *testing.T) {
+	dir, clean := tempDir(t)
+	defer clean()
+	src := filepath.Join(dir, "src")
+	if err := os.Mkdir(src, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	tpl := `<template><div><p v-memo="[id]">{{ id }}</p></div></template>`
+	if err := ioutil.WriteFile(filepath.Join(src, "page.vue"), []byte(tpl), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GenAllFile(src, filepath.Join(dir, "a"), "vuetpl"); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenAllFile("src", "b", "vuetpl"); err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := ioutil.ReadFile(filepath.Join(dir, "a", "page.vue.go"))
+	b, _ := ioutil.ReadFile(filepath.Join(dir, "b", "page.vue.go"))
+	if !bytes.Contains(a, []byte(`"page.vue:0"`)) {
+		t.Fatalf("memo key should be relative to src: %s", a)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatalf("code = %s; want: %s", b, a)
+	}
+}
+
+func TestGenAllFileSplit(t *testing.T) {
+	desc, clean := tempDir(t)
+	defer clean()
+	err := GenAllFile("./test_src/tree_shaking", desc, "vuetpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(desc, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range files {
+		_, name := filepath.Split(f)
+		names = append(names, name)
+
+		file, err := parser.ParseFile(token.NewFileSet(), f, nil, parser.PackageClauseOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if file.Name.Name != "vuetpl" {
+			t.Fatalf("%s: package = %s; want: vuetpl", name, file.Name.Name)
+		}
+	}
+
+	want := "builtin.go,creator.go,infoCard.vue.go,orphan.vue.go,page.vue.go"
+	if strings.Join(names, ",") != want {
+		t.Fatalf("files = %v; want: %s", names, want)
+	}
+}
+
+// 一个模板出错不会中断编译, 其他组件仍然会生成, 所有错误合并返回
+func TestGenAllFileCompileErrors(t *testing.T) {
+	desc, clean := tempDir(t)
+	defer clean()
+	err := GenAllFile("./test_src/compile_errors", desc, "vuetpl")
+	es, ok := err.(CompileErrors)
+	if !ok || len(es) != 2 {
+		t.Fatalf("want 2 errs, but: %v", err)
+	}
+	if !strings.Contains(err.Error(), "broken.vue:4: v-else") ||
+		!strings.Contains(err.Error(), "badExpr.vue:3: ") {
+		t.Fatalf("err should report broken.vue and badExpr.vue: %v", err)
+	}
+
+	for _, f := range []string{"good.vue.go", "other.vue.go", "broken.vue.go", "badExpr.vue.go", "creator.go", "builtin.go"} {
+		code, err := ioutil.ReadFile(filepath.Join(desc, f))
+		if err != nil {
+			t.Fatalf("%s should be emitted: %v", f, err)
+		}
+		if _, err := format.Source(code); err != nil {
+			t.Fatalf("%s compile err: %v", f, err)
+		}
+	}
+
+	good, _ := ioutil.ReadFile(filepath.Join(desc, "good.vue.go"))
+	if !strings.Contains(string(good), "xx_other(r, w, ") {
+		t.Fatalf("good should be compiled: %s", good)
+	}
+	// 出错的组件不记录src_hash, 下次编译时会重新编译
+	broken, _ := ioutil.ReadFile(filepath.Join(desc, "broken.vue.go"))
+	if !strings.Contains(string(broken), "// src_hash:\n") {
+		t.Fatalf("broken should not have src_hash: %s", broken)
+	}
+}
+
+// 编译错误包含模板, 行号与分类
+func TestCompileErrorFields(t *testing.T) {
+	c := NewCompiler()
+	c.StrictAttrs = true
+	c.ValidateKey = true
+	c.AllowedFuncs = map[string]bool{}
+	c.Source = MapSource{
+		"tpl/else.vue": "<template>\n  <div>\n    <p v-else>a</p>\n  </div>\n</template>",
+		"tpl/dup.vue":  "<template>\n  <p class=\"a\" class=\"b\">a</p>\n</template>",
+		"tpl/expr.vue": "<template>\n  <div>\n    <p v-if=\"a = 1\">a</p>\n  </div>\n</template>",
+		"tpl/key.vue":  "<template>\n  <ul>\n    <li v-for=\"item in list\" :key=\"itm.id\">a</li>\n  </ul>\n</template>",
+		"tpl/func.vue": "<template>\n  <div>\n\n    <p>{{ exec('rm') }}</p>\n  </div>\n</template>",
+	}
+
+	cases := []struct {
+		file     string
+		line     int
+		category ErrorCategory
+		msg      string
+	}{
+		{"tpl/else.vue", 3, CategoryDirective, "v-else must below v-if"},
+		{"tpl/dup.vue", 2, CategoryDuplicateAttr, `duplicate attribute "class" on <p>`},
+		{"tpl/expr.vue", 3, CategoryExpression, `assignment is not allowed in expression "a = 1", did you mean "=="?`},
+		{"tpl/key.vue", 3, CategoryKey, `:key expression "itm.id" does not reference v-for variable "item" or "$index"`},
+		{"tpl/func.vue", 4, CategoryForbiddenFunc, `function "exec" is not allowed in expression "exec('rm')"`},
+		{"tpl/missing.vue", 0, CategoryRead, ""},
+	}
+	for _, tc := range cases {
+		// 解析错误在加载时返回, 生成代码时的错误在编译时返回
+		err := c.LoadTemplates(tc.file)
+		if err == nil {
+			_, err = compileComponent(c, "vuetpl", componentName(strings.TrimSuffix(filepath.Base(tc.file), ".vue")), tc.file, "")
+			if err != nil {
+				err = CompileErrors{newCompileError(tc.file, err)}
+			}
+		}
+		es, ok := err.(CompileErrors)
+		if !ok || len(es) != 1 {
+			t.Fatalf("%s: want CompileErrors, but: %v", tc.file, err)
+		}
+		e := es[0]
+		if e.File != tc.file || e.Line != tc.line || e.Category != tc.category || (tc.msg != "" && e.Msg != tc.msg) {
+			t.Fatalf("%s: err = %+v; want line %d, category %s, msg %s", tc.file, e, tc.line, tc.category, tc.msg)
+		}
+	}
+}
+
+// 被标签分隔的插值在编译时报错, 而不是把{{原样输出
+func TestUnterminatedInterpolation(t *testing.T) {
+	c := NewCompiler()
+	c.Source = MapSource{
+		"tpl/split.vue": "<template>\n  <p>\n    Hi {{ user.name <b>}}</b>\n  </p>\n</template>",
+		"tpl/ok.vue":    "<template>\n  <p>{{ a }} and {{ b }}</p>\n</template>",
+	}
+	err := c.LoadTemplates("tpl/split.vue", "tpl/ok.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := compileComponent(c, "vuetpl", "ok", "tpl/ok.vue", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = compileComponent(c, "vuetpl", "split", "tpl/split.vue", "")
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("want *ParseError, but: %v", err)
+	}
+	e := newCompileError("tpl/split.vue", pe)
+	if e.Category != CategoryInterpolation || e.Line != 2 || !strings.Contains(e.Msg, `unterminated interpolation {{ user.name`) {
+		t.Fatalf("err = %+v", e)
+	}
+}
+
+// 条件是字面量时在编译期计算, 为false的分支不会生成代码
+func TestConstVIf(t *testing.T) {
+	c := NewCompiler()
+	c.Source = MapSource{
+		"tpl/off.vue":    "<template>\n  <div>\n    <p v-if=\"false\"><b>{{ secret }}</b></p>\n  </div>\n</template>",
+		"tpl/on.vue":     "<template>\n  <div>\n    <p v-if=\"true\">{{ on }}</p>\n    <p v-else>{{ secret }}</p>\n  </div>\n</template>",
+		"tpl/branch.vue": "<template>\n  <div>\n    <p v-if=\"0\">{{ secret }}</p>\n    <p v-else-if=\"a\">{{ a }}</p>\n    <p v-else-if=\"''\">{{ secret }}</p>\n    <p v-else>{{ b }}</p>\n  </div>\n</template>",
+	}
+	err := c.LoadTemplates("tpl/off.vue", "tpl/on.vue", "tpl/branch.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		want    []string
+		notWant []string
+	}{
+		{"off", nil, []string{"secret", "<p", "interfaceToBool"}},
+		{"on", []string{`scope.Get("on")`}, []string{"secret", "interfaceToBool"}},
+		{"branch", []string{`if interfaceToBool(scope.Get("a"))`, "} else {", `scope.Get("b")`}, []string{"secret"}},
+	} {
+		bs, err := compileComponent(c, "vuetpl", tc.name, "tpl/"+tc.name+".vue", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		code := string(bs)
+		for _, s := range tc.want {
+			if !strings.Contains(code, s) {
+				t.Fatalf("%s: code should contain %s: %s", tc.name, s, code)
+			}
+		}
+		for _, s := range tc.notWant {
+			if strings.Contains(code, s) {
+				t.Fatalf("%s: code should not contain %s: %s", tc.name, s, code)
+			}
+		}
+	}
+}
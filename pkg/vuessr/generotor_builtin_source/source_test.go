@@ -1,6 +1,11 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -130,3 +135,568 @@ func Test_getAttrFromProps(t *testing.T) {
 	}))
 	t.Logf("%+v", as)
 }
+
+func TestSanitizeURL(t *testing.T) {
+	cases := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/a?b=1#c", "https://example.com/a?b=1#c"},
+		{"/path/to a", "/path/to%20a"},
+		{"?q=\"<x>\"", "?q=%22%3Cx%3E%22"},
+		{"mailto:a@b.com", "mailto:a@b.com"},
+		{"javascript:alert(1)", unsafeURL},
+		{" JavaScript:alert(1)", unsafeURL},
+		{"data:text/html,<script>", unsafeURL},
+		{"/a:b", "/a:b"},
+		{"中文", "%E4%B8%AD%E6%96%87"},
+	}
+
+	for _, c := range cases {
+		if got := sanitizeURL(c.url); got != c.want {
+			t.Fatalf("sanitizeURL(%q) = %s; want: %s", c.url, got, c.want)
+		}
+	}
+}
+
+func TestTagSanitizeURL(t *testing.T) {
+	c := newRenderCreator()
+	c.SanitizeURL = true
+	r := c.NewRender()
+	w := r.NewWriter()
+
+	// 组件上的:href.attr与:src会被根节点继承, 它们没有在编译期清理
+	parent := &Options{
+		Attrs: []Attribute{{Key: "href", Val: "javascript:alert(1)"}},
+		Props: NewProps(map[string]interface{}{"src": " javascript:alert(2)"}),
+	}
+	_tag(r, w, "a", true, &Options{
+		Attrs: []Attribute{{Key: "href", Val: "javascript:void(0)"}},
+		Props: NewProps(map[string]interface{}{"action": "/a b"}),
+		P:     parent,
+	})
+	// 节点上的静态属性是模板中写的, 不会被清理
+	want := `<a href="javascript:void(0)" action="/a%20b" href="about:invalid#unsafe" src="about:invalid#unsafe"></a>`
+	if html := w.Result(); html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	c.SanitizeURL = false
+	r = c.NewRender()
+	w = r.NewWriter()
+	_tag(r, w, "a", true, &Options{P: parent})
+	if html, want := w.Result(), `<a href="javascript:alert(1)" src=" javascript:alert(2)"></a>`; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+}
+
+func TestMixinAttrCanonical(t *testing.T) {
+	props := Props{}
+	props.Set("title", "t")
+	props.Set("id", "x")
+
+	attr := mixinAttrCanonical(nil, []Attribute{{Key: "href", Val: "/"}, {Key: "data-a", Val: "1"}}, props)
+	if want := ` id="x" data-a="1" href="/" title="t"`; attr != want {
+		t.Fatalf("attr = %s; want: %s", attr, want)
+	}
+}
+
+func TestMixinClassDedup(t *testing.T) {
+	options := &Options{Class: []string{"card", "parent"}, PropsClass: "active parent"}
+	class := mixinClass(options, []string{"btn", "card"}, map[string]interface{}{"btn": true, "active": true})
+	if want := ` class="btn card active parent"`; class != want {
+		t.Fatalf("class = %s; want: %s", class, want)
+	}
+}
+
+func TestCachedGet(t *testing.T) {
+	r := newRenderCreator().NewRender()
+	profile := map[string]interface{}{"name": "a"}
+	scope := extendScope(r.Global, map[string]interface{}{"user": map[string]interface{}{"profile": profile}})
+
+	if v := cachedGet(r, scope, "user", "profile", "name"); v != "a" {
+		t.Fatalf("name = %v; want: a", v)
+	}
+	// 结果在本次渲染中被缓存
+	profile["name"] = "b"
+	if v := cachedGet(r, scope, "user", "profile", "name"); v != "a" {
+		t.Fatalf("cached name = %v; want: a", v)
+	}
+	// Set后缓存失效
+	scope.Set("user", map[string]interface{}{"profile": map[string]interface{}{"name": "c"}})
+	if v := cachedGet(r, scope, "user", "profile", "name"); v != "c" {
+		t.Fatalf("name after set = %v; want: c", v)
+	}
+
+	// 循环中的变量不会被缓存
+	for _, name := range []string{"x", "y"} {
+		s := acquireScope(r, scope)
+		s.Set("item", map[string]interface{}{"name": name})
+		if v := cachedGet(r, s, "item", "name"); v != name {
+			t.Fatalf("item.name = %v; want: %s", v, name)
+		}
+		releaseScope(r, s)
+	}
+}
+
+func TestMixinAttrXHTML(t *testing.T) {
+	props := NewProps(map[string]interface{}{})
+	props.Set("disabled", true)
+	props.Set("checked", false)
+	props.Set("aria-pressed", true)
+	static := []Attribute{{Key: "readonly"}}
+
+	if attr, want := mixinAttr(nil, static, props), ` readonly disabled aria-pressed="true"`; attr != want {
+		t.Fatalf("html attr = %s; want: %s", attr, want)
+	}
+	if attr, want := mixinAttrXHTML(nil, static, props, false), ` readonly="readonly" disabled="disabled" aria-pressed="true"`; attr != want {
+		t.Fatalf("xhtml attr = %s; want: %s", attr, want)
+	}
+}
+
+func TestEmptyBool(t *testing.T) {
+	for _, c := range []struct {
+		v          interface{}
+		str, empty string
+	}{
+		{true, "true", ""},
+		{false, "false", ""},
+		{0, "0", "0"},
+		{"false", "false", "false"},
+	} {
+		if s := interfaceToStr(c.v, true); s != c.str {
+			t.Fatalf("%v: str = %q; want: %q", c.v, s, c.str)
+		}
+		if s := interfaceToStr(emptyBool(c.v), true); s != c.empty {
+			t.Fatalf("%v: empty = %q; want: %q", c.v, s, c.empty)
+		}
+	}
+}
+
+func TestLocaleDir(t *testing.T) {
+	for locale, want := range map[string]string{
+		"ar":      "rtl",
+		"fa-IR":   "rtl",
+		"ur_PK":   "rtl",
+		"az-Arab": "rtl",
+		"ku-Latn": "ltr",
+		"en-US":   "ltr",
+		"zh-Hans": "ltr",
+		"":        "ltr",
+	} {
+		if dir := LocaleDir(locale); dir != want {
+			t.Fatalf("LocaleDir(%q) = %s; want: %s", locale, dir, want)
+		}
+	}
+}
+
+func TestExtractStyle(t *testing.T) {
+	c := newRenderCreator()
+	c.ExtractStyles = true
+	r := c.NewRender()
+
+	attr := r.extractStyle(nil, []string{"a"}, nil, map[string]string{"color": "red"}, map[string]interface{}{"margin": "0"})
+	if want := ` class="a s-`; !strings.HasPrefix(attr, want) || strings.Contains(attr, "style") {
+		t.Fatalf("attr = %s; want prefix: %s", attr, want)
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(attr, ` class="a `), `"`)
+
+	// 相同的样式使用同一个class, 样式表中只有一条规则
+	if attr2 := r.extractStyle(nil, nil, nil, map[string]string{"margin": "0", "color": "red"}, nil); attr2 != ` class="`+name+`"` {
+		t.Fatalf("attr = %s; want class %s", attr2, name)
+	}
+	if css, want := r.Styles(), "."+name+"{color: red; margin: 0;}"; css != want {
+		t.Fatalf("styles = %s; want: %s", css, want)
+	}
+
+	// 没有style时不生成class
+	if attr := r.extractStyle(nil, []string{"b"}, nil, nil, nil); attr != ` class="b"` {
+		t.Fatalf("attr = %s", attr)
+	}
+	// css中的<不能结束<style>节点
+	r.extractStyle(nil, nil, nil, map[string]string{"content": "'</style>'"}, nil)
+	if css := r.Styles(); strings.Contains(css, "</style") {
+		t.Fatalf("styles = %s", css)
+	}
+}
+
+// hash相同的不同css使用不同的class
+func TestExtractStyleHashCollision(t *testing.T) {
+	r := newRenderCreator().NewRender()
+	// "costarring"与"liquid"的32位FNV-1a hash相同
+	a, b := r.styleClass("costarring"), r.styleClass("liquid")
+	if a == b {
+		t.Fatalf("class = %s, %s; want different", a, b)
+	}
+	if b != a+"-1" {
+		t.Fatalf("class = %s; want: %s", b, a+"-1")
+	}
+	if a2, b2 := r.styleClass("costarring"), r.styleClass("liquid"); a2 != a || b2 != b {
+		t.Fatalf("class = %s, %s; want: %s, %s", a2, b2, a, b)
+	}
+	if css, want := r.Styles(), "."+a+"{costarring}\n."+b+"{liquid}"; css != want {
+		t.Fatalf("styles = %s; want: %s", css, want)
+	}
+}
+
+// 样式表中的动态style使用css转义而不是html转义, 不能结束当前规则
+func TestExtractStyleEscape(t *testing.T) {
+	c := newRenderCreator()
+	c.ExtractStyles = true
+	r := c.NewRender()
+
+	attr := r.extractStyle(nil, nil, nil, nil, map[string]interface{}{
+		"color":       "red} body{display:none",
+		"font-family": `"A&B"`,
+	})
+	name := strings.TrimSuffix(strings.TrimPrefix(attr, ` class="`), `"`)
+	want := "." + name + `{color: red\7d  body\7b display:none; font-family: "A&B";}`
+	if css := r.Styles(); css != want {
+		t.Fatalf("styles = %s; want: %s", css, want)
+	}
+
+	for src, want := range map[string]string{
+		`a\b`:        `a\5c b`,
+		"x;y":        `x\3b y`,
+		"/* c */":    `/\2a  c */`,
+		"a\nb":       `a\a b`,
+		"</style>":   `\3c /style>`,
+		"url(a.png)": "url(a.png)",
+	} {
+		if got := cssEscape(src); got != want {
+			t.Fatalf("cssEscape(%q) = %q; want: %q", src, got, want)
+		}
+	}
+
+	// 作为属性输出时仍然是html转义
+	if got, want := mixinStyle(nil, nil, map[string]interface{}{"font-family": `"A&B"`}), ` style="font-family: &#34;A&amp;B&#34;;"`; got != want {
+		t.Fatalf("style = %s; want: %s", got, want)
+	}
+}
+
+func TestExecFilterUnknown(t *testing.T) {
+	c := newRenderCreator()
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+
+	if v := execFilter(r, "raw", "<b>"); v != RawHTML("<b>") {
+		t.Fatalf("raw = %v", v)
+	}
+	if v := execFilter(r, "nope", "a"); v != "a" {
+		t.Fatalf("nope = %v; want: a", v)
+	}
+	if len(warns) != 1 || warns[0] != `unknown filter "nope"` {
+		t.Fatalf("warns = %v", warns)
+	}
+}
+
+func TestRenderNilPlaceholder(t *testing.T) {
+	c := newRenderCreator()
+	r := c.NewRender()
+	w := r.NewWriter()
+	r.Render("missing", w, &Options{})
+	if html, want := w.Result(), "<p>not register component: missing</p>"; html != want {
+		t.Fatalf("html = %s; want: %s", html, want)
+	}
+
+	c.Placeholder = nil
+	r = c.NewRender()
+	w = r.NewWriter()
+	r.Render("missing", w, &Options{})
+	if html := w.Result(); html != "" {
+		t.Fatalf("html = %s; want empty", html)
+	}
+}
+
+func TestForLimit(t *testing.T) {
+	c := newRenderCreator()
+	c.MaxForIterations = 2
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+
+	if d := forSlice(r, []int{1, 2, 3}); len(d) != 2 || cap(d) != 2 {
+		t.Fatalf("slice = %v, cap = %d", d, cap(d))
+	}
+	if d := forSlice(r, [3]string{"a", "b", "c"}); !reflect.DeepEqual(d, []interface{}{"a", "b"}) {
+		t.Fatalf("array = %v", d)
+	}
+	var keys []interface{}
+	forRange(r, map[string]int{"a": 1, "b": 2, "c": 3}, func(index interface{}, item interface{}) {
+		keys = append(keys, index)
+	})
+	if len(keys) != 2 {
+		t.Fatalf("map = %v", keys)
+	}
+	var items []interface{}
+	forRange(r, func(yield func(interface{}) bool) {
+		for i := 0; i < 5 && yield(i); i++ {
+		}
+	}, func(index interface{}, item interface{}) {
+		items = append(items, item)
+	})
+	if len(items) != 2 {
+		t.Fatalf("iterator = %v", items)
+	}
+
+	want := "v-for iterations truncated to MaxForIterations(2)"
+	if len(warns) != 4 || warns[0] != want || warns[1] != want || warns[2] != want || warns[3] != want {
+		t.Fatalf("warns = %v", warns)
+	}
+}
+
+// 等待channel中的数据时渲染被取消, 需要停止等待
+func TestForRangeChanCanceled(t *testing.T) {
+	r := newRenderCreator().NewRender()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.ctx = ctx
+	r.done = ctx.Done()
+
+	ch := make(chan int)
+	rendered := make(chan struct{})
+	go func() {
+		ch <- 1
+		<-rendered
+		cancel()
+	}()
+
+	var items []interface{}
+	forRange(r, ch, func(index interface{}, item interface{}) {
+		items = append(items, item)
+		close(rendered)
+	})
+	if !reflect.DeepEqual(items, []interface{}{1}) {
+		t.Fatalf("items = %v", items)
+	}
+	if r.cancelErr != context.Canceled {
+		t.Fatalf("cancelErr = %v", r.cancelErr)
+	}
+}
+
+func TestBindProps(t *testing.T) {
+	c := newRenderCreator()
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+	props := NewProps(map[string]interface{}{"b": "bound"})
+
+	for _, obj := range []interface{}{
+		map[string]interface{}{"a": "1", "b": "2"},
+		map[string]string{"a": "1", "b": "2"},
+		NewProps(map[string]interface{}{"a": "1", "b": "2"}),
+	} {
+		p := bindProps(r, obj, props)
+		if a, _ := p.Get("a"); a != "1" {
+			t.Fatalf("%T: a = %v", obj, a)
+		}
+		if b, _ := p.Get("b"); b != "bound" {
+			t.Fatalf("%T: b = %v; want: bound", obj, b)
+		}
+	}
+	if len(warns) != 0 {
+		t.Fatalf("warns = %v", warns)
+	}
+
+	p := bindProps(r, []string{"a"}, props)
+	if _, ok := p.Get("a"); ok || len(warns) != 1 {
+		t.Fatalf("props = %v, warns = %v", p.Map(), warns)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	// 时间戳不受服务器时区的影响
+	local := time.Local
+	time.Local = time.FixedZone("X", 8*3600)
+	defer func() { time.Local = local }()
+
+	if s := formatDate(int64(0), "2006-01-02 15:04", nil); s != "1970-01-01 00:00" {
+		t.Fatalf("date = %s", s)
+	}
+	loc := time.FixedZone("Y", 3600)
+	if s := formatDate(0, "2006-01-02 15:04", loc); s != "1970-01-01 01:00" {
+		t.Fatalf("date = %s", s)
+	}
+	// time.Time使用自身的时区
+	tm := time.Date(2020, 1, 2, 3, 4, 0, 0, loc)
+	if s := formatDate(tm, "2006-01-02 15:04", nil); s != "2020-01-02 03:04" {
+		t.Fatalf("date = %s", s)
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	for _, c := range []struct {
+		f        float64
+		decimals int
+		locale   string
+		want     string
+	}{
+		{1234567.891, 2, "", "1,234,567.89"},
+		{-1234.5, -1, "en-US", "-1,234.5"},
+		{1234.5, 1, "de_DE", "1.234,5"},
+		{999, 0, "unknown", "999"},
+		{math.NaN(), 2, "", "NaN"},
+		{math.Inf(1), 2, "de", "Infinity"},
+		{math.Inf(-1), -1, "", "-Infinity"},
+	} {
+		if s := formatNumber(c.f, c.decimals, c.locale); s != c.want {
+			t.Fatalf("formatNumber(%v, %d, %s) = %s; want: %s", c.f, c.decimals, c.locale, s, c.want)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	for _, c := range []struct {
+		count interface{}
+		forms []interface{}
+		want  string
+	}{
+		{1, []interface{}{"item"}, "item"},
+		{2, []interface{}{"item"}, "items"},
+		{0, []interface{}{"child", "children"}, "children"},
+		{1.0, []interface{}{"child", "children"}, "child"},
+		{3, nil, ""},
+	} {
+		if s := plural(c.count, c.forms...); s != c.want {
+			t.Fatalf("plural(%v, %v) = %s; want: %s", c.count, c.forms, s, c.want)
+		}
+	}
+}
+
+func TestNumRange(t *testing.T) {
+	for _, c := range []struct {
+		args []interface{}
+		want string
+	}{
+		{[]interface{}{3}, "[0 1 2]"},
+		{[]interface{}{1, 4}, "[1 2 3]"},
+		{[]interface{}{1, 4, 2}, "[1 3]"},
+		{[]interface{}{5, 0, -2}, "[5 3 1]"},
+		{[]interface{}{0, 5, 0}, "[]"},
+		{[]interface{}{5, 1}, "[]"},
+		{nil, "[]"},
+	} {
+		if s := fmt.Sprint(numRange(nil, c.args...)); s != c.want {
+			t.Fatalf("range(%v) = %s; want: %s", c.args, s, c.want)
+		}
+	}
+
+	// 超过MaxForIterations时在分配之前截断
+	c := newRenderCreator()
+	c.MaxForIterations = 3
+	var warns []string
+	c.Warn = func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+	r := c.NewRender()
+	d := numRange(r.forLimit, 1e12)
+	if fmt.Sprint(d) != "[0 1 2]" || cap(d) != 3 {
+		t.Fatalf("range(1e12) = %v, cap = %d", d, cap(d))
+	}
+	if len(warns) != 1 {
+		t.Fatalf("warns = %v", warns)
+	}
+}
+
+func TestNonceAttr(t *testing.T) {
+	r := newRenderCreator().NewRender()
+	if s := nonceAttr(r); s != "" {
+		t.Fatalf("nonce = %s", s)
+	}
+
+	r.Nonce = `a"b`
+	if s, want := nonceAttr(r), ` nonce="a&#34;b"`; s != want {
+		t.Fatalf("nonce = %s; want: %s", s, want)
+	}
+}
+
+func TestLocaleAttr(t *testing.T) {
+	r := newRenderCreator().NewRender()
+	if s := localeAttr(r, "lang", "en"); s != ` lang="en"` {
+		t.Fatalf("lang = %s", s)
+	}
+	if s := localeAttr(r, "dir", ""); s != "" {
+		t.Fatalf("dir = %s", s)
+	}
+
+	// Locale代替模板中的值
+	r.Locale = "ar_EG"
+	if s, want := localeAttr(r, "lang", "en"), ` lang="ar-EG"`; s != want {
+		t.Fatalf("lang = %s; want: %s", s, want)
+	}
+	if s, want := localeAttr(r, "dir", "ltr"), ` dir="rtl"`; s != want {
+		t.Fatalf("dir = %s; want: %s", s, want)
+	}
+
+	r.Locale = `a"b`
+	if s, want := localeAttr(r, "lang", ""), ` lang="a&#34;b"`; s != want {
+		t.Fatalf("lang = %s; want: %s", s, want)
+	}
+}
+
+// 数字按大小排序, 字符串按字典序, nil在最前, 不同类型按fmt.Sprint的结果
+func TestSortedMapKeys(t *testing.T) {
+	keys := sortedMapKeys(reflect.ValueOf(map[interface{}]int{10: 0, 2: 0, "b": 0, "a": 0, nil: 0}))
+	var s []string
+	for _, k := range keys {
+		s = append(s, fmt.Sprint(k.Interface()))
+	}
+	if got, want := strings.Join(s, ","), "<nil>,2,10,a,b"; got != want {
+		t.Fatalf("keys = %s; want: %s", got, want)
+	}
+
+	keys = sortedMapKeys(reflect.ValueOf(map[int]int{10: 0, 2: 0, -1: 0}))
+	if keys[0].Int() != -1 || keys[1].Int() != 2 || keys[2].Int() != 10 {
+		t.Fatalf("keys = %v", keys)
+	}
+}
+
+func TestCoerceProp(t *testing.T) {
+	for _, c := range []struct {
+		kind PropKind
+		v    interface{}
+		want interface{}
+	}{
+		{PropString, 1, "1"},
+		{PropNumber, " 12 ", 12},
+		{PropNumber, "1.5", 1.5},
+		{PropBool, "", true},
+		{PropBool, "false", false},
+		{PropAny, "x", "x"},
+	} {
+		v, err := coerceProp(c.kind, c.v)
+		if err != nil || v != c.want {
+			t.Fatalf("coerceProp(%d, %#v) = %#v, %v; want: %#v", c.kind, c.v, v, err, c.want)
+		}
+	}
+
+	if _, err := coerceProp(PropNumber, "abc"); err == nil {
+		t.Fatal("want error")
+	}
+	if _, err := coerceProp(PropBool, 1); err == nil {
+		t.Fatal("want error")
+	}
+}
+
+// 浏览器会忽略<textarea>/<pre>内容开头的一个换行
+func TestKeepLeadingNewline(t *testing.T) {
+	r := newRenderCreator().NewRender()
+	for text, want := range map[string]string{
+		"a":     "a",
+		"\na":   "\n\na",
+		"\r\na": "\n\r\na",
+	} {
+		w := r.NewWriter()
+		keepLeadingNewline(r, w, func(w Writer) { w.WriteString(text) })
+		if s := w.Result(); s != want {
+			t.Fatalf("%q: html = %q; want: %q", text, s, want)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package parser
 
 import (
+	"bufio"
 	"fmt"
 	"github.com/zbysir/go-vue-ssr/internal/pkg/html"
 	"github.com/zbysir/go-vue-ssr/internal/pkg/html/atom"
+	"io"
 	"os"
 	"strings"
 )
@@ -13,36 +15,52 @@ import (
 // - 不支持不规则的html, 已知的有<select>里嵌套<slot>, 在<head>里嵌套<div>, 其实还有很多未知的问题, 为了避免引起未知bug, vue模板不需要做html的规则检查.
 // 还在寻求另一个解决方案.
 type GoHtml struct {
+	// 是否保留注释节点, 默认忽略注释
+	KeepComments bool
 }
 
 func (g GoHtml) Parse(html string) (es []*Element, err error) {
-	return parseHtml(html)
+	return parseHtml(html, g.KeepComments)
+}
+
+// ParseReader 从r中解析html, 用于模板不在文件系统中的情况
+func (g GoHtml) ParseReader(r io.Reader) (es []*Element, err error) {
+	return parseHtmlReader(r, g.KeepComments)
 }
 
 // parse HTML
-func parseHtml(filename string) (es []*Element, err error) {
+func parseHtml(filename string, keepComments bool) (es []*Element, err error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
+	return parseHtmlReader(file, keepComments)
+}
+
+func parseHtmlReader(r io.Reader, keepComments bool) (es []*Element, err error) {
 	var nodes []*html.Node
 
-	// 两个情况: 一种是<template>开头的 则是标准的vue组件, 一种vue组件如html页面. 但为了简化流程, html页面也可以被当为vue组件来渲染.
-	peekWant := "<template"
-	peek := make([]byte, len(peekWant))
-	_, err = file.Read(peek)
+	// 两种情况: 一种是以<!DOCTYPE>/<html>开头的html页面, 会按完整的html文档解析(自动补全<head>/<body>);
+	// 其他的(<template>开头的标准vue组件, 或多个顶层节点组成的片段)按html片段解析, 见Render.RenderFragment.
+	// 多读一些, 以便跳过开头较长的注释(如版权声明)
+	file := bufio.NewReaderSize(r, 4096)
+	peek, err := file.Peek(4096)
+	if err == io.EOF || err == bufio.ErrBufferFull {
+		// 内容比4096短
+		err = nil
+	}
 	if err != nil {
 		return
 	}
-	_, _ = file.Seek(0, 0)
 
-	if string(peek) == peekWant {
+	if !isDocument(peek) {
+		// 使用<template>作为上下文, 这样<tr>/<td>等只能出现在特定父节点中的节点也能作为顶层节点
 		root := &html.Node{
 			Type:     html.ElementNode,
-			DataAtom: atom.Div,
-			Data:     atom.Div.String(),
+			DataAtom: atom.Template,
+			Data:     atom.Template.String(),
 		}
 		nodes, err = html.ParseFragment(file, root)
 		if err != nil {
@@ -66,11 +84,29 @@ func parseHtml(filename string) (es []*Element, err error) {
 		}
 	}
 
-	es = hNodeToElement(nodes)
+	es = hNodeToElement(nodes, keepComments)
 	return
 }
 
-func hNodeToElement(nodes []*html.Node) []*Element {
+// 是否是完整的html文档: 以<!DOCTYPE>或<html>开头(忽略大小写, 以及前面的BOM, 空白与注释)
+func isDocument(peek []byte) bool {
+	head := strings.ToLower(strings.TrimPrefix(string(peek), "\ufeff"))
+	for {
+		head = strings.TrimLeft(head, " \t\r\n\f")
+		if !strings.HasPrefix(head, "<!--") {
+			break
+		}
+		end := strings.Index(head[4:], "-->")
+		if end == -1 {
+			// 注释超出了peek的长度, 无法判断
+			return false
+		}
+		head = head[4+end+3:]
+	}
+	return strings.HasPrefix(head, "<!doctype") || strings.HasPrefix(head, "<html")
+}
+
+func hNodeToElement(nodes []*html.Node, keepComments bool) []*Element {
 	var es []*Element
 	for _, node := range nodes {
 		var e Element
@@ -104,7 +140,14 @@ func hNodeToElement(nodes []*html.Node) []*Element {
 				TagName:  node.Data,
 			}
 		case html.CommentNode:
-			omitNode = true
+			if !keepComments {
+				omitNode = true
+				break
+			}
+			e = Element{
+				NodeType: CommentNode,
+				Text:     node.Data,
+			}
 		case html.DoctypeNode:
 			e = Element{
 				NodeType: DoctypeNode,
@@ -127,11 +170,12 @@ func hNodeToElement(nodes []*html.Node) []*Element {
 				c = c.NextSibling
 			}
 
-			children = hNodeToElement(allC)
+			children = hNodeToElement(allC, keepComments)
 		}
 
 		e.Children = children
 		e.Attrs = node.Attr
+		e.Line = node.Line
 
 		es = append(es, &e)
 	}
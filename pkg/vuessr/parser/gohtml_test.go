@@ -2,6 +2,9 @@ package parser
 
 import (
 	"encoding/json"
+	"github.com/zbysir/go-vue-ssr/internal/pkg/html"
+	"github.com/zbysir/go-vue-ssr/internal/pkg/html/atom"
+	"strings"
 	"testing"
 )
 
@@ -16,3 +19,109 @@ func TestGoHtmlParse(t *testing.T) {
 	bs, _ := json.MarshalIndent(x, " ", " ")
 	t.Logf("%s", bs)
 }
+
+func TestGoHtmlParseReader(t *testing.T) {
+	p := GoHtml{}
+	x, err := p.ParseReader(strings.NewReader(`<template><p>a</p></template>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(x) != 1 || x[0].TagName != "template" || x[0].Children[0].TagName != "p" {
+		bs, _ := json.Marshal(x)
+		t.Fatalf("want <template><p>, but: %s", bs)
+	}
+
+	// 比<template短的内容也能解析
+	_, err = p.ParseReader(strings.NewReader(`<p>a</p>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 不是完整html文档的内容按片段解析, 多个顶层节点都会保留
+func TestGoHtmlParseFragment(t *testing.T) {
+	p := GoHtml{}
+	x, err := p.ParseReader(strings.NewReader("\n<tr><td>a</td></tr><tr><td>b</td></tr><li>c</li>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tags []string
+	for _, e := range x {
+		tags = append(tags, e.TagName)
+	}
+	if strings.Join(tags, ",") != "tr,tr,li" {
+		bs, _ := json.Marshal(x)
+		t.Fatalf("want tr,tr,li, but: %s", bs)
+	}
+
+	x, err = p.ParseReader(strings.NewReader("<!DOCTYPE html><p>a</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(x) != 2 || x[1].TagName != "html" {
+		bs, _ := json.Marshal(x)
+		t.Fatalf("want html document, but: %s", bs)
+	}
+}
+
+// 开头的BOM与注释不影响完整html文档的判断
+func TestGoHtmlParseDocumentLeadingComment(t *testing.T) {
+	p := GoHtml{}
+	for _, src := range []string{
+		"\ufeff<!DOCTYPE html><p>a</p>",
+		"<!-- license -->\n<!doctype html><p>a</p>",
+		"\ufeff<!-- a --><!-- b -->\n<html><p>a</p></html>",
+		"<!-- " + strings.Repeat("x", 1000) + " --><html><p>a</p></html>",
+	} {
+		x, err := p.ParseReader(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(x) == 0 || x[len(x)-1].TagName != "html" {
+			bs, _ := json.Marshal(x)
+			t.Fatalf("%q: want html document, but: %s", src, bs)
+		}
+	}
+
+	x, err := p.ParseReader(strings.NewReader("<!-- a --><p>a</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(x) != 1 || x[0].TagName != "p" {
+		bs, _ := json.Marshal(x)
+		t.Fatalf("want fragment, but: %s", bs)
+	}
+}
+
+// 片段以<template>作为上下文解析, 普通的节点和以<div>作为上下文时解析的结果一样
+func TestGoHtmlParseFragmentContext(t *testing.T) {
+	for _, src := range []string{
+		`<div><p>a</p><p>b</div>`,
+		`<ul><li>a<li>b</ul><span>c</span>`,
+		`<table><tr><td>a</td></tr></table>`,
+		`<select><option>a</option></select>`,
+		`<p>a<div>b</div>`,
+		`text <b>c</b>`,
+		`<form><input name="a"></form><img src="a.png">`,
+	} {
+		got, err := parseHtmlReader(strings.NewReader(src), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+			Type:     html.ElementNode,
+			DataAtom: atom.Div,
+			Data:     atom.Div.String(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := hNodeToElement(nodes, false)
+
+		gotBs, _ := json.Marshal(got)
+		wantBs, _ := json.Marshal(want)
+		if string(gotBs) != string(wantBs) {
+			t.Fatalf("%s: %s; want: %s", src, gotBs, wantBs)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package vuessr
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// TemplateSource 模板的来源, 解析模板时会通过它读取模板内容, 默认从文件系统读取.
+// 实现它可以从embed.FS, 数据库等地方读取模板, name为模板的路径, 如LoadTemplates的参数.
+type TemplateSource interface {
+	Open(name string) (io.ReadCloser, error)
+}
+
+// OSSource 从文件系统读取模板
+type OSSource struct{}
+
+func (OSSource) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// MapSource 从内存中读取模板, key是模板的路径, value是模板内容
+type MapSource map[string]string
+
+func (m MapSource) Open(name string) (io.ReadCloser, error) {
+	s, ok := m[name]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return ioutil.NopCloser(strings.NewReader(s)), nil
+}
+
+// 读取模板内容
+func (c *Compiler) readTemplate(filename string) ([]byte, error) {
+	if c.Source == nil {
+		return ioutil.ReadFile(filename)
+	}
+	r, err := c.Source.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
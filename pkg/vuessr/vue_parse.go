@@ -1,10 +1,66 @@
 package vuessr
 
 import (
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/internal/pkg/html"
 	"github.com/zbysir/go-vue-ssr/pkg/vuessr/parser"
+	"io"
 	"strings"
 )
 
+// 模板错误的分类, 方便工具按分类处理错误
+type ErrorCategory string
+
+const (
+	CategoryDirective     ErrorCategory = "directive"      // 指令使用错误, 如没有与v-if相邻的v-else
+	CategoryDuplicateAttr ErrorCategory = "duplicate-attr" // 重复的属性, 见VueElementParser.StrictAttrs
+	CategoryExpression    ErrorCategory = "expression"     // 不能解析的表达式
+	CategoryInterpolation ErrorCategory = "interpolation"  // 没有闭合的{{, 如插值被标签分隔
+	CategoryKey           ErrorCategory = "key"            // v-for上错误的:key, 见Compiler.ValidateKey
+	CategoryForbiddenFunc ErrorCategory = "forbidden-func" // 调用了不允许的方法, 见Compiler.AllowedFuncs
+	CategoryForbiddenVar  ErrorCategory = "forbidden-var"  // 读取了不允许的变量, 见Compiler.AllowedVars
+	CategoryComplexity    ErrorCategory = "complexity"     // 表达式过于复杂, 见Compiler.MaxExprDepth
+	CategoryRead          ErrorCategory = "read"           // 读取模板失败
+	CategoryInternal      ErrorCategory = "internal"       // 其他错误
+)
+
+// 模板的书写错误, 如没有与v-if相邻的v-else
+// 解析与生成代码时遇到的错误都会转为ParseError, 见toParseError
+type ParseError struct {
+	File     string
+	Line     int // 出错节点的行号, 为0时表示未知
+	Msg      string
+	Category ErrorCategory
+}
+
+func (e *ParseError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	}
+	if e.Line == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// 将生成代码时的panic转为*ParseError, 没有行号的错误会使用line
+func toParseError(r interface{}, line int) *ParseError {
+	var pe *ParseError
+	switch e := r.(type) {
+	case *ParseError:
+		pe = e
+	case error:
+		// 生成代码时的error都来自表达式的解析
+		pe = &ParseError{Msg: e.Error(), Category: CategoryExpression}
+	default:
+		pe = &ParseError{Msg: fmt.Sprint(r), Category: CategoryInternal}
+	}
+	if pe.Line == 0 {
+		pe.Line = line
+	}
+	return pe
+}
+
 type VueElement struct {
 	// 是否是root节点
 	// 正常情况下template下的第一个节点是root节点, 如 template > div.
@@ -23,11 +79,19 @@ type VueElement struct {
 	Style            map[string]string // 静态style
 	StyleKeys        []string          // 样式的key, 用来保证顺序
 	Props            Props             // props, 包括动态的class和style
+	AttrProps        Props             // :foo.attr, 总是被渲染为attr, 即使作用在组件上
 	Children         []*VueElement     // 子节点
 	VIf              *VIf              // 处理v-if需要的数据
 	VFor             *VFor
 	VSlot            *VSlot
-	VElse            bool // 如果是VElse节点则不会生成代码(而是在vif里生成代码)
+	VLet             []VLet // 声明模板局部变量, 只计算一次
+	VBind            string // v-bind="obj", 绑定整个对象
+	Key              string // :key表达式, ssr不会输出key, 只在ValidateKey时用于编译期校验
+	BuildIf          string // v-build-if="amp", 编译期的条件, 见Compiler.BuildFlags
+	Memo             string // v-memo="[a, b]", 依赖的值不变时复用缓存的渲染结果
+	Join             string // v-join=", ", 和v-for一起使用, 在每次循环的内容之间输出分隔符
+	Head             bool   // v-head, 节点不在原位置渲染, 而是收集到Render.Head中
+	VElse            bool   // 如果是VElse节点则不会生成代码(而是在vif里生成代码)
 	VElseIf          bool
 	// v-html / v-text
 	// 支持v-html / v-text指令覆盖子级内容的组件有: template / html基本标签
@@ -35,10 +99,12 @@ type VueElement struct {
 	VHtml string
 	VText string
 	VOn   []VOnDirective // v-on与普通自定义指令不同，其中表达式不会去调用方法，而是存储调用的方法和args然后生成js代码
+	Line  int            // 在.vue文件中的行号, 用于生成代码注释
 }
 
 type Attribute struct {
 	Key, Val string
+	NoValue  bool // 是否是没有值的属性, 如<toggle active>, 见componentAttrs
 }
 
 type Directive struct {
@@ -52,16 +118,53 @@ type Directive struct {
 //  如a+1中我们无法得知a到底是读取props(翻译成go代码)还是使用全局的js变量（不翻译）。
 // v-on:click="a=a+1" // 表达式 不支持：同上
 type VOnDirective struct {
-	Func  string // buttonClick
-	Args  string // args1, args2, 将被翻译成go。
-	Exp   string // 原始表达式: buttonClick(args1, args2)
-	Event string // click
+	Func      string   // buttonClick
+	Args      string   // args1, args2, 将被翻译成go。
+	Exp       string   // 原始表达式: buttonClick(args1, args2)
+	Event     string   // click
+	Modifiers []string // 事件修饰符, 如@click.prevent.once中的[prevent, once]
+}
+
+// 规范化的事件key, 如v-on:click.prevent.once, 用于Compiler.Hydrate时输出为attr
+func (d VOnDirective) Key() string {
+	return "v-on:" + strings.Join(append([]string{d.Event}, d.Modifiers...), ".")
+}
+
+// 将事件属性的key解析为事件名和修饰符
+// 支持: @click.prevent / v-on:click.once / click.capture.passive
+func parseEventKey(key string) (event string, modifiers []string) {
+	key = strings.TrimPrefix(key, "@")
+	key = strings.TrimPrefix(key, "v-on:")
+
+	return splitModifiers(key)
+}
+
+// 将指令的key按'.'分为名字与修饰符, 如v-for.one会得到v-for与[one]
+func splitModifiers(key string) (name string, modifiers []string) {
+	ss := strings.Split(key, ".")
+	name = ss[0]
+	for _, m := range ss[1:] {
+		if m != "" {
+			modifiers = append(modifiers, m)
+		}
+	}
+	return
+}
+
+func hasModifier(modifiers []string, m string) bool {
+	for _, v := range modifiers {
+		if v == m {
+			return true
+		}
+	}
+	return false
 }
 
 type ElseIf struct {
 	Types      string // else / elseif
 	Condition  string // elseif语句的condition表达式
 	VueElement *VueElement
+	Comments   []*VueElement // 与上一个分支之间的注释(见VueElementParser.KeepComments), 和这个分支一起输出, 保持源码中的顺序
 }
 
 type VIf struct {
@@ -77,6 +180,7 @@ type VFor struct {
 	ArrayKey string
 	ItemKey  string
 	IndexKey string
+	OneBased bool // v-for.one, 下标从1开始
 }
 
 type VSlot struct {
@@ -84,6 +188,12 @@ type VSlot struct {
 	PropsKey string
 }
 
+// v-let:name="expr", 声明一个在当前节点(包括子节点)中可以使用的变量
+type VLet struct {
+	Name  string
+	Value string
+}
+
 func (p Props) Omit(key ...string) Props {
 	kMap := map[string]struct{}{}
 	for _, k := range key {
@@ -101,19 +211,53 @@ func (p Props) Omit(key ...string) Props {
 }
 
 func ParseVue(filename string) (v *VueElement, err error) {
-	htmlParser := parser.GoHtml{}
+	return VueElementParser{}.ParseFile(filename)
+}
+
+// 解析.vue文件
+func (p VueElementParser) ParseFile(filename string) (v *VueElement, err error) {
+	// 解析时遇到模板错误会panic(*ParseError), 在这里转为error返回
+	defer func() {
+		if r := recover(); r != nil {
+			pe, ok := r.(*ParseError)
+			if !ok {
+				panic(r)
+			}
+			pe.File = filename
+			err = pe
+		}
+	}()
 
-	es, err := htmlParser.Parse(filename)
+	htmlParser := parser.GoHtml{KeepComments: p.KeepComments}
+
+	var es []*parser.Element
+	if p.Source != nil {
+		var r io.ReadCloser
+		r, err = p.Source.Open(filename)
+		if err != nil {
+			return
+		}
+		defer r.Close()
+		es, err = htmlParser.ParseReader(r)
+	} else {
+		es, err = htmlParser.Parse(filename)
+	}
 	if err != nil {
 		return
 	}
 
-	p := VueElementParser{}
+	if p.Warn != nil {
+		warn := p.Warn
+		p.Warn = func(format string, args ...interface{}) {
+			warn("%s:"+format, append([]interface{}{filename}, args...)...)
+		}
+	}
 	if len(es) == 1 {
 		v = p.Parse(es[0])
 
 		// 和vue不同的是, 在根template下的所有子节点都是root节点
 		// 这样可以实现在组件上方添加一些指令, 而不破坏组件
+		// v-if/v-else的每个分支也都是root节点, 所以无论渲染哪个分支都会继承上层传递的class/style
 		if v.TagName == "template" {
 			for _, v := range v.Children {
 				v.IsRoot = true
@@ -133,6 +277,36 @@ func ParseVue(filename string) (v *VueElement, err error) {
 }
 
 type VueElementParser struct {
+	// 节点上有重复的属性(如id="a" id="b")时是否报错(*ParseError), 默认只会调用Warn.
+	// 不报错时与浏览器一致: 第一个属性生效, 之后的被忽略
+	StrictAttrs bool
+	// 输出解析时的警告, 为nil时不输出
+	Warn func(format string, args ...interface{})
+	// 读取模板的来源, 为nil时从文件系统读取
+	Source TemplateSource
+	// 是否保留模板中的注释, 默认忽略注释
+	KeepComments bool
+}
+
+// 去掉节点上重复的属性, 只保留第一个
+func (p VueElementParser) uniqAttrs(e *parser.Element) []html.Attribute {
+	attrs := make([]html.Attribute, 0, len(e.Attrs))
+	seen := make(map[string]bool, len(e.Attrs))
+	for _, attr := range e.Attrs {
+		if seen[attr.Key] {
+			msg := fmt.Sprintf("duplicate attribute %q on <%s>", attr.Key, e.TagName)
+			if p.StrictAttrs {
+				panic(&ParseError{Line: e.Line, Msg: msg, Category: CategoryDuplicateAttr})
+			}
+			if p.Warn != nil {
+				p.Warn("%d: %s, the first one is used", e.Line, msg)
+			}
+			continue
+		}
+		seen[attr.Key] = true
+		attrs = append(attrs, attr)
+	}
+	return attrs
 }
 
 func (p VueElementParser) Parse(e *parser.Element) *VueElement {
@@ -146,8 +320,15 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 	vs := make([]*VueElement, len(es))
 
 	var ifVueEle *VueElement
+	// 上一个节点是否是v-else, 用于提示v-else之后的v-else/v-else-if
+	afterElse := false
+	// v-if之后的注释, 如果之后是v-else/v-else-if, 注释会被放入分支中, 见ElseIf.Comments
+	var chainComments []int
+	// 放入了分支中的注释, 不再作为子节点
+	inChain := map[int]bool{}
 	for i, e := range es {
 		var props []Prop
+		var attrProps []Prop
 		var ds []Directive
 		var vOn []VOnDirective
 		var class []string
@@ -157,6 +338,13 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 		var vIf *VIf
 		var vFor *VFor
 		var vSlot *VSlot
+		var vLet []VLet
+		var vBind string
+		var vKey string
+		var vBuildIf string
+		var vMemo string
+		var vJoin string
+		var vHead bool
 
 		// 标记节点是不是if
 		var vElse *ElseIf
@@ -165,7 +353,7 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 		var vHtml string
 		var vText string
 
-		for _, attr := range e.Attrs {
+		for _, attr := range p.uniqAttrs(e) {
 			oriKey := attr.Key
 			ss := strings.Split(oriKey, ":")
 			nameSpace := "-"
@@ -175,7 +363,16 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 				nameSpace = ss[0]
 			}
 
-			if nameSpace == "v-bind" || nameSpace == "" {
+			if (nameSpace == "v-bind" || nameSpace == "") && key == "key" {
+				// :key 只在客户端diff时有用, 服务端渲染时丢弃
+				vKey = strings.Trim(attr.Val, " ")
+			} else if (nameSpace == "v-bind" || nameSpace == "") && strings.HasSuffix(key, ".attr") {
+				// :foo.attr 强制渲染为attr
+				attrProps = append(attrProps, Prop{
+					Key: strings.TrimSuffix(key, ".attr"),
+					Val: attr.Val,
+				})
+			} else if nameSpace == "v-bind" || nameSpace == "" {
 				// v-bind & shorthands :
 				props = append(props, Prop{
 					Key: key,
@@ -191,22 +388,24 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 					args := attr.Val[start+1 : end]
 					fun := attr.Val[:start]
 
-					event := strings.TrimPrefix(key, "@")
+					event, modifiers := parseEventKey(key)
 
 					vOn = append(vOn, VOnDirective{
-						Func:  fun,
-						Args:  args,
-						Event: event,
-						Exp:   attr.Val,
+						Func:      fun,
+						Args:      args,
+						Event:     event,
+						Exp:       attr.Val,
+						Modifiers: modifiers,
 					})
 				} else {
 					// func
-					event := strings.TrimPrefix(key, "@")
+					event, modifiers := parseEventKey(key)
 					vOn = append(vOn, VOnDirective{
-						Func:  attr.Val,
-						Args:  "",
-						Event: event,
-						Exp:   attr.Val,
+						Func:      attr.Val,
+						Args:      "",
+						Event:     event,
+						Exp:       attr.Val,
+						Modifiers: modifiers,
 					})
 				}
 			} else if strings.HasPrefix(oriKey, "v-") {
@@ -216,8 +415,9 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 				// v-else-if=""
 				// v-else
 				// v-html
+				dirName, modifiers := splitModifiers(key)
 				switch {
-				case key == "v-for":
+				case dirName == "v-for":
 					val := attr.Val
 
 					ss := strings.Split(val, " in ")
@@ -243,6 +443,7 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 						ArrayKey: arrayKey,
 						ItemKey:  itemKey,
 						IndexKey: indexKey,
+						OneBased: hasModifier(modifiers, "one"),
 					}
 				case key == "v-if":
 					vIf = &VIf{
@@ -260,6 +461,21 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 						SlotName: slotName,
 						PropsKey: propsKey,
 					}
+				case nameSpace == "v-region":
+					// v-region:name, 将节点(包括节点自身)放入组件的name插槽中, 用于从其他模板引擎迁移的布局组件
+					// 与v-slot不同的是, v-region用在普通节点上, 并且没有插槽作用域
+					vSlot = &VSlot{
+						SlotName: key,
+						PropsKey: "slotProps",
+					}
+				case key == "v-bind":
+					// v-bind="obj"
+					vBind = strings.Trim(attr.Val, " ")
+				case nameSpace == "v-let":
+					vLet = append(vLet, VLet{
+						Name:  key,
+						Value: strings.Trim(attr.Val, " "),
+					})
 				case key == "v-else-if":
 					vElseIf = &ElseIf{
 						Types:     "elseif",
@@ -270,6 +486,15 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 						Types:     "else",
 						Condition: strings.Trim(attr.Val, " "),
 					}
+				case key == "v-head":
+					vHead = true
+				case key == "v-memo":
+					vMemo = strings.Trim(attr.Val, " ")
+				case key == "v-join":
+					// 分隔符是字面量, 不是表达式
+					vJoin = attr.Val
+				case key == "v-build-if":
+					vBuildIf = strings.Trim(attr.Val, " ")
 				case key == "v-html":
 					vHtml = strings.Trim(attr.Val, " ")
 				case key == "v-text":
@@ -316,8 +541,9 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 					key = attr.Namespace + ":" + attr.Key
 				}
 				attrs = append(attrs, Attribute{
-					Key: key,
-					Val: attr.Val,
+					Key:     key,
+					Val:     attr.Val,
+					NoValue: attr.NoValue,
 				})
 			}
 		}
@@ -337,15 +563,24 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 			Style:            style,
 			StyleKeys:        styleKeys,
 			Props:            props,
+			AttrProps:        attrProps,
 			Children:         ch,
 			VIf:              vIf,
 			VFor:             vFor,
 			VSlot:            vSlot,
+			VLet:             vLet,
+			VBind:            vBind,
+			Key:              vKey,
+			BuildIf:          vBuildIf,
+			Memo:             vMemo,
+			Join:             vJoin,
+			Head:             vHead,
 			VElse:            vElse != nil,
 			VElseIf:          vElseIf != nil,
 			VHtml:            vHtml,
 			VText:            vText,
 			VOn:              vOn,
+			Line:             e.Line,
 		}
 
 		// 记录vif, 接下来的elseif将与这个节点关联
@@ -358,25 +593,65 @@ func (p VueElementParser) parseList(es []*parser.Element) []*VueElement {
 				ifVueEle = nil
 			}
 		}
+		if ifVueEle == nil || vIf != nil {
+			chainComments = nil
+		}
 
 		if vElseIf != nil {
+			if afterElse {
+				panic(&ParseError{Line: e.Line, Msg: "v-else-if after v-else", Category: CategoryDirective})
+			}
 			if ifVueEle == nil {
-				panic("v-else-if must below v-if")
+				panic(&ParseError{Line: e.Line, Msg: "v-else-if must below v-if", Category: CategoryDirective})
 			}
 			vElseIf.VueElement = v
+			vElseIf.Comments = takeComments(vs, chainComments, inChain)
+			chainComments = nil
 			ifVueEle.VIf.AddElseIf(vElseIf)
 		}
 		if vElse != nil {
+			if afterElse {
+				panic(&ParseError{Line: e.Line, Msg: "v-else after v-else", Category: CategoryDirective})
+			}
 			if ifVueEle == nil {
-				panic("v-else must below v-if")
+				panic(&ParseError{Line: e.Line, Msg: "v-else must below v-if", Category: CategoryDirective})
 			}
 			vElse.VueElement = v
+			vElse.Comments = takeComments(vs, chainComments, inChain)
+			chainComments = nil
 			ifVueEle.VIf.AddElseIf(vElse)
 			ifVueEle = nil
 		}
 
+		if vElse != nil {
+			afterElse = true
+		} else if e.NodeType != parser.CommentNode {
+			afterElse = false
+		}
+		if ifVueEle != nil && e.NodeType == parser.CommentNode {
+			chainComments = append(chainComments, i)
+		}
+
 		vs[i] = v
 	}
 
+	if len(inChain) != 0 {
+		rest := make([]*VueElement, 0, len(vs)-len(inChain))
+		for i, v := range vs {
+			if !inChain[i] {
+				rest = append(rest, v)
+			}
+		}
+		vs = rest
+	}
 	return vs
 }
+
+// 取出v-if分支之间的注释
+func takeComments(vs []*VueElement, index []int, inChain map[int]bool) (comments []*VueElement) {
+	for _, i := range index {
+		comments = append(comments, vs[i])
+		inChain[i] = true
+	}
+	return
+}
@@ -2,6 +2,11 @@ package vuessr
 
 import (
 	"encoding/json"
+	"fmt"
+	"github.com/zbysir/go-vue-ssr/internal/pkg/html"
+	"github.com/zbysir/go-vue-ssr/pkg/vuessr/parser"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +19,129 @@ func TestParseVue(t *testing.T) {
 	bs, _ := json.MarshalIndent(e, " ", " ")
 	t.Logf("%s", bs)
 }
+
+func TestParseEventKey(t *testing.T) {
+	cases := []struct {
+		key       string
+		event     string
+		modifiers []string
+	}{
+		{"@click", "click", nil},
+		{"@click.prevent", "click", []string{"prevent"}},
+		{"@click.once", "click", []string{"once"}},
+		{"@scroll.passive", "scroll", []string{"passive"}},
+		{"@click.self.capture", "click", []string{"self", "capture"}},
+		{"click.prevent.stop.once", "click", []string{"prevent", "stop", "once"}},
+		{"v-on:submit.prevent", "submit", []string{"prevent"}},
+	}
+
+	for _, c := range cases {
+		event, modifiers := parseEventKey(c.key)
+		if event != c.event || !reflect.DeepEqual(modifiers, c.modifiers) {
+			t.Fatalf("parseEventKey(%s) = %s, %v; want: %s, %v", c.key, event, modifiers, c.event, c.modifiers)
+		}
+	}
+}
+
+func TestParseVOnModifiers(t *testing.T) {
+	e := VueElementParser{}.Parse(&parser.Element{
+		NodeType: parser.ElementNode,
+		TagName:  "button",
+		Attrs: []html.Attribute{
+			{Key: "@click.prevent.once", Val: "submit(id)"},
+			{Key: "v-on:mouseover.passive", Val: "hover"},
+		},
+	})
+
+	want := []VOnDirective{
+		{Func: "submit", Args: "id", Exp: "submit(id)", Event: "click", Modifiers: []string{"prevent", "once"}},
+		{Func: "hover", Args: "", Exp: "hover", Event: "mouseover", Modifiers: []string{"passive"}},
+	}
+	if !reflect.DeepEqual(e.VOn, want) {
+		t.Fatalf("VOn = %+v; want: %+v", e.VOn, want)
+	}
+	// 事件不会被当作attr渲染
+	if len(e.Attrs) != 0 || len(e.Props) != 0 {
+		t.Fatalf("event should not be attr, attrs: %+v, props: %+v", e.Attrs, e.Props)
+	}
+}
+
+func TestParseVForModifiers(t *testing.T) {
+	cases := []struct {
+		key      string
+		oneBased bool
+	}{
+		{"v-for", false},
+		{"v-for.one", true},
+		{"v-for.foo.one", true},
+		{"v-for.foo", false},
+	}
+
+	for _, c := range cases {
+		e := VueElementParser{}.Parse(&parser.Element{
+			NodeType: parser.ElementNode,
+			TagName:  "li",
+			Attrs:    []html.Attribute{{Key: c.key, Val: "item in list"}},
+		})
+		if e.VFor == nil || e.VFor.OneBased != c.oneBased || len(e.Directives) != 0 {
+			t.Fatalf("%s: VFor = %+v, directives: %+v", c.key, e.VFor, e.Directives)
+		}
+	}
+}
+
+func TestParseVueElseChain(t *testing.T) {
+	cases := []struct {
+		file string
+		want string
+	}{
+		{"else_else.vue", "else_else.vue:5: v-else after v-else"},
+		{"else_elseif.vue", "else_elseif.vue:5: v-else-if after v-else"},
+		{"orphan_else.vue", "orphan_else.vue:4: v-else must below v-if"},
+	}
+
+	for _, c := range cases {
+		_, err := ParseVue("./test_src/bad_else/" + c.file)
+		if err == nil {
+			t.Fatalf("%s: want error", c.file)
+		}
+		if _, ok := err.(*ParseError); !ok || !strings.HasSuffix(err.Error(), c.want) {
+			t.Fatalf("%s: err = %v; want: %s", c.file, err, c.want)
+		}
+	}
+
+	if _, err := ParseVue("./test_src/bad_else/ok.vue"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseVueDuplicateAttr(t *testing.T) {
+	var warns []string
+	p := VueElementParser{Warn: func(format string, args ...interface{}) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}}
+	e, err := p.ParseFile("./test_src/dup_attr/dup.vue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 第一个属性生效
+	pe := e.Children[0].Children[0]
+	if len(pe.Attrs) != 1 || pe.Attrs[0].Key != "id" || pe.Attrs[0].Val != "x" {
+		t.Fatalf("attrs = %+v; want: id=x", pe.Attrs)
+	}
+	if len(pe.Class) != 1 || pe.Class[0] != "a" {
+		t.Fatalf("class = %+v; want: [a]", pe.Class)
+	}
+	want := []string{
+		`./test_src/dup_attr/dup.vue:3: duplicate attribute "class" on <p>, the first one is used`,
+		`./test_src/dup_attr/dup.vue:3: duplicate attribute "id" on <p>, the first one is used`,
+	}
+	if strings.Join(warns, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("warns = %q; want: %q", warns, want)
+	}
+
+	_, err = VueElementParser{StrictAttrs: true}.ParseFile("./test_src/dup_attr/dup.vue")
+	if _, ok := err.(*ParseError); !ok || err.Error() != `./test_src/dup_attr/dup.vue:3: duplicate attribute "class" on <p>` {
+		t.Fatalf("err = %v", err)
+	}
+}